@@ -3,8 +3,10 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/http"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/commands"
@@ -14,7 +16,17 @@ import (
 
 // isActionCommand checks if a command is a preset action command
 func isActionCommand(cmd string) bool {
-	return cmd == "set" || cmd == "get" || cmd == "edit" || cmd == "call"
+	return cmd == "set" || cmd == "get" || cmd == "edit" || cmd == "call" || cmd == "lint" || cmd == "rm"
+}
+
+// wantsHelp checks for --help/-h, which works without a current preset
+func wantsHelp(args []string) bool {
+	for _, arg := range args {
+		if arg == "--help" || arg == "-h" {
+			return true
+		}
+	}
+	return false
 }
 
 
@@ -35,12 +47,34 @@ func main() {
 		return
 	}
 
+	// Hidden entry point for the shell completion scripts
+	if args[0] == "__complete" {
+		words := args[1:]
+		// PowerShell can't pass an empty argument, so it flags a new word instead
+		if len(words) > 0 && words[0] == "--new-word" {
+			words = append(words[1:], "")
+		}
+		for _, candidate := range commands.Complete(words, sessionManager.GetCurrentPreset()) {
+			fmt.Println(candidate)
+		}
+		return
+	}
+
+	// User settings: a broken config.toml is reported, then the built-ins take over
+	// saul config reports it itself
+	settings, err := config.LoadConfig()
+	if err != nil && args[0] != "config" {
+		fmt.Fprintf(os.Stderr, display.WarnConfigIgnored+"\n", err)
+	}
+	display.SetColorMode(settings.ColorMode())
+
 	// Inject current preset for action commands
-	if len(args) > 0 && isActionCommand(args[0]) {
+	// 'saul rm preset <name...>' is the one rm that doesn't work on the current preset
+	if len(args) > 0 && isActionCommand(args[0]) && !(args[0] == "rm" && len(args) > 1 && core.IsPresetKeyword(args[1])) {
 		if sessionManager.HasCurrentPreset() {
 			// Inject preset: ["set", "body"] -> ["pokeapi", "set", "body"]
 			args = append([]string{sessionManager.GetCurrentPreset()}, args...)
-		} else {
+		} else if !wantsHelp(args) {
 			// Error: action command but no current preset
 			display.Error(display.ErrNoCurrentPreset)
 			return
@@ -71,6 +105,9 @@ func executeCommand(cmd core.Command, sessionManager *core.SessionManager) error
 
 	// Update current preset when explicitly specified and save to session
 	if cmd.Preset != "" {
+		if err := workspace.ValidatePresetName(cmd.Preset); err != nil {
+			return err
+		}
 		err := sessionManager.SetCurrentPreset(cmd.Preset)
 		if err != nil {
 			// Session save failure is not critical - log but continue
@@ -80,7 +117,7 @@ func executeCommand(cmd core.Command, sessionManager *core.SessionManager) error
 
 	// Handle global commands
 	if cmd.Global != "" {
-		return executeGlobalCommand(cmd)
+		return executeGlobalCommand(cmd, sessionManager)
 	}
 
 	// Handle preset commands
@@ -88,7 +125,7 @@ func executeCommand(cmd core.Command, sessionManager *core.SessionManager) error
 }
 
 // executeGlobalCommand handles global commands like list, rm, version
-func executeGlobalCommand(cmd core.Command) error {
+func executeGlobalCommand(cmd core.Command, sessionManager *core.SessionManager) error {
 	switch cmd.Global {
 	case "version":
 		display.Info(utils.GetVersionInfo())
@@ -96,49 +133,60 @@ func executeGlobalCommand(cmd core.Command) error {
 		return nil
 
 	case "rm":
-		if len(cmd.Targets) == 0 {
-			return fmt.Errorf("preset name required for rm command")
-		}
+		return commands.ExecuteDeleteCommand(cmd)
 
-		// Handle multiple targets: saul rm preset1 preset2 preset3
-		// Continue processing, warn about non-existent presets
-		var warnings []string
-		deletedCount := 0
-
-		for _, presetName := range cmd.Targets {
-			err := workspace.DeletePreset(presetName)
-			if err != nil {
-				// Collect warnings for non-existent presets, continue processing
-				warnings = append(warnings, fmt.Sprintf("Warning: preset '%s' does not exist", presetName))
-			} else {
-				deletedCount++
-			}
+	case "help":
+		if cmd.Target != "" {
+			return showCommandHelp(cmd.Target)
 		}
+		showHelp()
+		return nil
 
-		// Print warnings if any
-		for _, warning := range warnings {
-			display.Warning(warning)
-		}
+	case "completion":
+		return commands.ExecuteCompletionCommand(cmd)
 
-		// Silent success if at least one was deleted, or no warnings
-		return nil
+	case "where":
+		return commands.Where(cmd)
 
-	case "help":
-		showHelp()
-		return nil
+	case "ls":
+		return commands.ListPresets(cmd)
+
+	case "search":
+		return commands.Search(cmd)
+
+	case "use", "pushd", "popd", "recent":
+		return commands.ExecuteNavigationCommand(cmd, sessionManager)
+
+	case "config":
+		return commands.ExecuteConfigCommand(cmd)
 
 	case "update":
 		return utils.HandleUpdateCommand()
 
+	case "cp", "mv":
+		return commands.ExecuteCopyCommand(cmd)
+
+	case "record":
+		return http.ExecuteRecordCommand(cmd)
+
+	case "request":
+		return http.ExecuteOneShotCommand(cmd)
+
+	case "import":
+		return commands.Import(cmd)
+
+	case "export":
+		return commands.Export(cmd)
+
 	default:
-		return fmt.Errorf("unknown global command: %s", cmd.Global)
+		return fmt.Errorf(display.ErrUnknownCommand, cmd.Global, "")
 	}
 }
 
 // executePresetCommand handles preset-specific commands
 func executePresetCommand(cmd core.Command) error {
 	if cmd.Preset == "" {
-		return fmt.Errorf("preset name required")
+		return fmt.Errorf(display.ErrPresetNameRequired)
 	}
 
 	// If no command specified, create the preset if it doesn't exist
@@ -163,11 +211,20 @@ func executePresetCommand(cmd core.Command) error {
 	case "edit":
 		err = commands.Edit(cmd)
 
+	case "rm":
+		err = commands.Rm(cmd)
+
 	case "call":
 		err = http.ExecuteCallCommand(cmd)
 
+	case "lint":
+		err = http.ExecuteLintCommand(cmd)
+
+	case "export":
+		err = commands.Export(cmd)
+
 	default:
-		return fmt.Errorf("unknown preset command: %s", cmd.Command)
+		return fmt.Errorf(display.ErrUnknownCommand, cmd.Command, "")
 	}
 
 	// If main command succeeded and --call flag is set, execute call
@@ -188,23 +245,12 @@ func showHelp() {
 	formatted := display.FormatSimpleSection("Usage", usage)
 	display.Plain(formatted)
 
-	// Global Commands section
-	globalCmds := `  saul version              Show version information
-  saul update               Check for updates
-  saul ls [options]         List presets directory (system ls command)
-  saul rm [preset...]       Delete one or more presets
-  saul help                 Show this help`
+	// Command sections come from the registry so help always matches the parser
+	globalCmds := formatCommandList(true)
 	formatted = display.FormatSimpleSection("Global Commands", globalCmds)
 	display.Plain(formatted)
 
-	// Preset Commands section
-	presetCmds := `  saul [preset]             Create or switch to preset
-  saul [preset] set [target] [key=value]
-                            Set value in target file
-  saul [preset] get [target] [key]
-                            Get value from target file
-  saul [preset] call        Execute HTTP request
-  saul call                 Execute HTTP request (current preset)`
+	presetCmds := formatUsageLine("[preset]", "Create or switch to preset") + "\n" + formatCommandList(false)
 	formatted = display.FormatSimpleSection("Preset Commands", presetCmds)
 	display.Plain(formatted)
 
@@ -213,7 +259,8 @@ func showHelp() {
   headers   HTTP headers
   query     Query/search payload data
   request   HTTP method, URL, and settings
-  variables Hard variables only (soft variables never stored)`
+  variables Hard variables only (soft variables never stored)
+  meta      Description, tags, owner and docs link (never sent)`
 	formatted = display.FormatSimpleSection("Targets", targets)
 	display.Plain(formatted)
 
@@ -233,4 +280,94 @@ func showHelp() {
   saul pokeapi get body pokemon.name`
 	formatted = display.FormatSimpleSection("Examples", examples)
 	display.Plain(formatted)
+	display.Tip("Run 'saul help <command>' for its flags and examples - set, get, call, import...")
+}
+
+// formatCommandList lists every global or preset command with its summary
+func formatCommandList(global bool) string {
+	var lines []string
+	for _, spec := range core.Commands {
+		if spec.Global != global {
+			continue
+		}
+		for i, usage := range spec.Usage {
+			summary := ""
+			if i == len(spec.Usage)-1 {
+				summary = spec.Summary
+			}
+			lines = append(lines, formatUsageLine(usage, summary))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatUsageLine aligns summaries in one column, wrapping below long usage lines
+func formatUsageLine(usage, summary string) string {
+	line := "  saul " + usage
+	if summary == "" {
+		return line
+	}
+	if len(line) < helpColumn-1 {
+		return line + strings.Repeat(" ", helpColumn-len(line)) + summary
+	}
+	return line + "\n" + strings.Repeat(" ", helpColumn) + summary
+}
+
+const helpColumn = 28
+
+// showCommandHelp prints usage, flags and examples for one command: saul help set
+func showCommandHelp(name string) error {
+	spec := core.LookupCommand(name, false)
+	if spec == nil {
+		spec = core.LookupCommand(name, true)
+	}
+	if spec == nil && (name == "METHOD" || isHTTPMethod(name)) {
+		spec = core.LookupCommand("request", true)
+	}
+	if spec == nil || (spec.Name == "request" && name == "request") {
+		var names []string
+		for _, command := range core.Commands {
+			names = append(names, command.Name)
+		}
+		return fmt.Errorf(display.ErrUnknownCommand, name, core.DidYouMean(name, names))
+	}
+
+	display.Info(spec.Summary)
+	display.Plain("")
+
+	var usage []string
+	for _, line := range spec.Usage {
+		usage = append(usage, "  saul "+line)
+	}
+	display.Plain(display.FormatSimpleSection("Usage", strings.Join(usage, "\n")))
+
+	if len(spec.Targets) > 0 {
+		display.Plain(display.FormatSimpleSection("Targets", "  "+strings.Join(spec.Targets, ", ")))
+	}
+
+	var flags []string
+	for _, flag := range append(spec.CommandFlags(), core.LookupFlag("help")) {
+		label := "  " + flag.Label()
+		if len(label) < helpColumn-1 {
+			flags = append(flags, label+strings.Repeat(" ", helpColumn-len(label))+flag.Usage)
+		} else {
+			flags = append(flags, label+"\n"+strings.Repeat(" ", helpColumn)+flag.Usage)
+		}
+	}
+	display.Plain(display.FormatSimpleSection("Flags", strings.Join(flags, "\n")))
+
+	if len(spec.Examples) > 0 {
+		display.Plain(display.FormatSimpleSection("Examples", "  "+strings.Join(spec.Examples, "\n  ")))
+	}
+	return nil
+}
+
+// isHTTPMethod checks for an uppercase method, the way one-shot requests are written
+func isHTTPMethod(name string) bool {
+	for _, method := range core.HTTPMethods {
+		if name == method {
+			return true
+		}
+	}
+	return false
 }
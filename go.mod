@@ -10,12 +10,13 @@ require (
 
 require (
 	github.com/tidwall/gjson v1.18.0
+	golang.org/x/sys v0.36.0
 	golang.org/x/term v0.35.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/tidwall/match v1.1.1 // indirect
 	github.com/tidwall/pretty v1.2.0 // indirect
 	golang.org/x/net v0.33.0 // indirect
-	golang.org/x/sys v0.36.0 // indirect
 )
@@ -0,0 +1,176 @@
+// Package codegen turns a preset into request snippets for other languages and tools.
+// Each target implements Generator, new targets only need to be added to the registry.
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
+)
+
+// Request is the language-neutral request every generator renders
+type Request struct {
+	Method  string
+	URL     string // Base URL without query string
+	Headers map[string]string
+	Query   map[string]string
+	Body    interface{} // Decoded JSON body, nil when the preset has none
+}
+
+// Generator renders a request as a snippet in one language or tool
+type Generator interface {
+	// Generate returns a complete, runnable snippet
+	Generate(req Request) string
+}
+
+// generators maps --lang names to their implementation
+var generators = map[string]Generator{
+	"go":         goGenerator{},
+	"python":     pythonGenerator{},
+	"js":         javascriptGenerator{},
+	"httpie":     httpieGenerator{},
+	"powershell": powershellGenerator{},
+}
+
+// Register adds or replaces a generator for a --lang name
+func Register(lang string, generator Generator) {
+	generators[strings.ToLower(lang)] = generator
+}
+
+// Lookup returns the generator for a --lang name
+func Lookup(lang string) (Generator, bool) {
+	generator, ok := generators[strings.ToLower(lang)]
+	return generator, ok
+}
+
+// Languages lists the registered --lang names
+func Languages() []string {
+	langs := make([]string, 0, len(generators))
+	for lang := range generators {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// FromHandlers builds a Request from a preset's request/headers/body/query files
+func FromHandlers(requestHandler, headersHandler, bodyHandler, queryHandler *workspace.TomlHandler) (Request, error) {
+	req := Request{
+		Method:  strings.ToUpper(requestHandler.GetAsString("method")),
+		URL:     requestHandler.GetAsString("url"),
+		Headers: make(map[string]string),
+		Query:   make(map[string]string),
+	}
+	if req.Method == "" {
+		req.Method = "GET"
+	}
+	if req.URL == "" {
+		return req, fmt.Errorf("preset has no URL configured")
+	}
+
+	for _, key := range headersHandler.Keys() {
+		req.Headers[key] = headersHandler.GetAsString(key)
+	}
+	for _, key := range queryHandler.Keys() {
+		req.Query[key] = queryHandler.GetAsString(key)
+	}
+
+	if len(bodyHandler.Keys()) > 0 {
+		data, err := bodyHandler.ToJSON()
+		if err != nil {
+			return req, fmt.Errorf("failed to convert body to JSON: %v", err)
+		}
+		if err := json.Unmarshal(data, &req.Body); err != nil {
+			return req, fmt.Errorf("failed to convert body to JSON: %v", err)
+		}
+	}
+
+	return req, nil
+}
+
+// HasHeader reports whether a header is set, ignoring case
+func (r Request) HasHeader(name string) bool {
+	for key := range r.Headers {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// FullURL appends the query string, leaving {@var}/{?var} placeholders readable
+func (r Request) FullURL() string {
+	if len(r.Query) == 0 {
+		return r.URL
+	}
+	var pairs []string
+	for _, key := range sortedKeys(r.Query) {
+		pairs = append(pairs, escapeQuery(key)+"="+escapeQuery(r.Query[key]))
+	}
+	separator := "?"
+	if strings.Contains(r.URL, "?") {
+		separator = "&"
+	}
+	return r.URL + separator + strings.Join(pairs, "&")
+}
+
+// BodyJSON renders the body as indented JSON
+func (r Request) BodyJSON(indent string) string {
+	return marshalNoHTML(r.Body, indent)
+}
+
+var placeholderPattern = regexp.MustCompile(`\{[@?]\w*\}`)
+
+// escapeQuery query-escapes a value without mangling saul placeholders
+func escapeQuery(value string) string {
+	var escaped strings.Builder
+	last := 0
+	for _, match := range placeholderPattern.FindAllStringIndex(value, -1) {
+		escaped.WriteString(url.QueryEscape(value[last:match[0]]))
+		escaped.WriteString(value[match[0]:match[1]])
+		last = match[1]
+	}
+	escaped.WriteString(url.QueryEscape(value[last:]))
+	return escaped.String()
+}
+
+// sortedKeys keeps generated snippets stable between runs
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// doubleQuote renders a double-quoted string literal (valid in Go, Python and JS)
+func doubleQuote(s string) string {
+	return marshalNoHTML(s, "")
+}
+
+// marshalNoHTML encodes JSON without the HTML escaping of <, > and &, which is noise in source code
+func marshalNoHTML(value interface{}, indent string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(value); err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// shellQuote wraps a value in single quotes for POSIX shells when it needs quoting
+func shellQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n'\"\\$`!*?[]{}()<>|&;#~") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
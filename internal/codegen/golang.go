@@ -0,0 +1,77 @@
+package codegen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// goGenerator renders a net/http program
+type goGenerator struct{}
+
+func (goGenerator) Generate(req Request) string {
+	imports := []string{"fmt", "io", "net/http"}
+	if len(req.Query) > 0 {
+		imports = append(imports, "net/url")
+	}
+	if req.Body != nil {
+		imports = append(imports, "strings")
+	}
+
+	var b strings.Builder
+	b.WriteString("package main\n\nimport (\n")
+	for _, pkg := range imports {
+		fmt.Fprintf(&b, "\t%q\n", pkg)
+	}
+	b.WriteString(")\n\nfunc main() {\n")
+
+	target := doubleQuote(req.URL)
+	if len(req.Query) > 0 {
+		b.WriteString("\tquery := url.Values{}\n")
+		for _, key := range sortedKeys(req.Query) {
+			fmt.Fprintf(&b, "\tquery.Set(%s, %s)\n", doubleQuote(key), doubleQuote(req.Query[key]))
+		}
+		b.WriteString("\n")
+		target += `+"?"+query.Encode()`
+	}
+
+	body := "nil"
+	if req.Body != nil {
+		fmt.Fprintf(&b, "\tbody := strings.NewReader(%s)\n", goRawString(req.BodyJSON("\t")))
+		body = "body"
+	}
+
+	fmt.Fprintf(&b, "\treq, err := http.NewRequest(%s, %s, %s)\n", doubleQuote(req.Method), target, body)
+	b.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	for _, key := range sortedKeys(req.Headers) {
+		fmt.Fprintf(&b, "\treq.Header.Set(%s, %s)\n", doubleQuote(key), doubleQuote(req.Headers[key]))
+	}
+	if req.Body != nil && !req.HasHeader("Content-Type") {
+		b.WriteString("\treq.Header.Set(\"Content-Type\", \"application/json\")\n")
+	}
+
+	b.WriteString(`
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		panic(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(resp.Status)
+	fmt.Println(string(data))
+}
+`)
+	return b.String()
+}
+
+// goRawString uses a backtick literal for readable JSON, falling back to a quoted one
+func goRawString(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
@@ -0,0 +1,44 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// httpieGenerator renders an HTTPie command line
+// Query params use name==value, headers Name:value, string fields name=value and other JSON name:=json
+type httpieGenerator struct{}
+
+func (httpieGenerator) Generate(req Request) string {
+	parts := []string{"http " + req.Method + " " + shellQuote(req.URL)}
+
+	for _, key := range sortedKeys(req.Query) {
+		parts = append(parts, shellQuote(key+"=="+req.Query[key]))
+	}
+	for _, key := range sortedKeys(req.Headers) {
+		parts = append(parts, shellQuote(key+":"+req.Headers[key]))
+	}
+
+	switch body := req.Body.(type) {
+	case nil:
+	case map[string]interface{}:
+		keys := make([]string, 0, len(body))
+		for key := range body {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if text, ok := body[key].(string); ok {
+				parts = append(parts, shellQuote(key+"="+text))
+			} else {
+				parts = append(parts, shellQuote(key+":="+marshalNoHTML(body[key], "")))
+			}
+		}
+	default:
+		// Non-object bodies go through stdin
+		return fmt.Sprintf("echo %s | %s\n", shellQuote(marshalNoHTML(body, "")), strings.Join(parts, " "))
+	}
+
+	return strings.Join(parts, " \\\n  ") + "\n"
+}
@@ -0,0 +1,42 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// javascriptGenerator renders a fetch call (Node 18+ or browser, ES module)
+type javascriptGenerator struct{}
+
+func (javascriptGenerator) Generate(req Request) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "const url = new URL(%s);\n", doubleQuote(req.URL))
+	for _, key := range sortedKeys(req.Query) {
+		fmt.Fprintf(&b, "url.searchParams.set(%s, %s);\n", doubleQuote(key), doubleQuote(req.Query[key]))
+	}
+
+	headers := make(map[string]string, len(req.Headers)+1)
+	for key, value := range req.Headers {
+		headers[key] = value
+	}
+	if req.Body != nil && !req.HasHeader("Content-Type") {
+		headers["Content-Type"] = "application/json"
+	}
+
+	b.WriteString("\nconst response = await fetch(url, {\n")
+	fmt.Fprintf(&b, "  method: %s,\n", doubleQuote(req.Method))
+	if len(headers) > 0 {
+		b.WriteString("  headers: {\n")
+		for _, key := range sortedKeys(headers) {
+			fmt.Fprintf(&b, "    %s: %s,\n", doubleQuote(key), doubleQuote(headers[key]))
+		}
+		b.WriteString("  },\n")
+	}
+	if req.Body != nil {
+		body := strings.ReplaceAll(req.BodyJSON("  "), "\n", "\n  ")
+		fmt.Fprintf(&b, "  body: JSON.stringify(%s),\n", body)
+	}
+	b.WriteString("});\n\n")
+	b.WriteString("console.log(response.status);\nconsole.log(await response.text());\n")
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// powershellGenerator renders an Invoke-RestMethod call
+// Single-quoted strings are used throughout so $ in values is never expanded
+type powershellGenerator struct{}
+
+func (powershellGenerator) Generate(req Request) string {
+	var b strings.Builder
+	args := []string{"-Uri " + psQuote(req.FullURL()), "-Method " + psMethod(req.Method)}
+
+	// Content-Type goes through -ContentType, Invoke-RestMethod ignores it as a header when a body is sent
+	var headerLines []string
+	for _, key := range sortedKeys(req.Headers) {
+		if req.Body == nil || !strings.EqualFold(key, "Content-Type") {
+			headerLines = append(headerLines, fmt.Sprintf("    %s = %s", psQuote(key), psQuote(req.Headers[key])))
+		}
+	}
+	if len(headerLines) > 0 {
+		b.WriteString("$headers = @{\n" + strings.Join(headerLines, "\n") + "\n}\n")
+		args = append(args, "-Headers $headers")
+	}
+
+	if req.Body != nil {
+		// A here-string keeps the JSON exactly as written
+		fmt.Fprintf(&b, "$body = @'\n%s\n'@\n", req.BodyJSON("  "))
+		contentType := "application/json"
+		for key, value := range req.Headers {
+			if strings.EqualFold(key, "Content-Type") {
+				contentType = value
+			}
+		}
+		args = append(args, "-ContentType "+psQuote(contentType), "-Body $body")
+	}
+
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "$response = Invoke-RestMethod %s\n", strings.Join(args, " "))
+	b.WriteString("$response | ConvertTo-Json -Depth 10\n")
+	return b.String()
+}
+
+// psQuote renders a single-quoted PowerShell string
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// psMethod turns POST into Post, the casing PowerShell's WebRequestMethod enum documents
+func psMethod(method string) string {
+	if method == "" {
+		return "Get"
+	}
+	return method[:1] + strings.ToLower(method[1:])
+}
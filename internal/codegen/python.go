@@ -0,0 +1,92 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// pythonGenerator renders a requests script
+type pythonGenerator struct{}
+
+func (pythonGenerator) Generate(req Request) string {
+	var b strings.Builder
+	b.WriteString("import requests\n\n")
+	fmt.Fprintf(&b, "url = %s\n", doubleQuote(req.URL))
+
+	args := []string{"url"}
+	if len(req.Query) > 0 {
+		b.WriteString("params = " + pythonDict(req.Query) + "\n")
+		args = append(args, "params=params")
+	}
+	if len(req.Headers) > 0 {
+		b.WriteString("headers = " + pythonDict(req.Headers) + "\n")
+		args = append(args, "headers=headers")
+	}
+	if req.Body != nil {
+		b.WriteString("payload = " + pythonLiteral(req.Body, "") + "\n")
+		args = append(args, "json=payload")
+	}
+
+	call := "requests." + strings.ToLower(req.Method)
+	switch req.Method {
+	case "GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS":
+	default:
+		call = "requests.request"
+		args = append([]string{doubleQuote(req.Method)}, args...)
+	}
+
+	fmt.Fprintf(&b, "\nresponse = %s(%s)\n", call, strings.Join(args, ", "))
+	b.WriteString("print(response.status_code)\nprint(response.text)\n")
+	return b.String()
+}
+
+// pythonDict renders a string map as a dict literal
+func pythonDict(m map[string]string) string {
+	values := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		values[key] = value
+	}
+	return pythonLiteral(values, "")
+}
+
+// pythonLiteral renders decoded JSON as a Python literal (True/False/None instead of true/false/null)
+func pythonLiteral(value interface{}, indent string) string {
+	inner := indent + "    "
+	switch typed := value.(type) {
+	case nil:
+		return "None"
+	case bool:
+		if typed {
+			return "True"
+		}
+		return "False"
+	case string:
+		return doubleQuote(typed)
+	case map[string]interface{}:
+		if len(typed) == 0 {
+			return "{}"
+		}
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var lines []string
+		for _, key := range keys {
+			lines = append(lines, inner+doubleQuote(key)+": "+pythonLiteral(typed[key], inner)+",")
+		}
+		return "{\n" + strings.Join(lines, "\n") + "\n" + indent + "}"
+	case []interface{}:
+		if len(typed) == 0 {
+			return "[]"
+		}
+		var lines []string
+		for _, item := range typed {
+			lines = append(lines, inner+pythonLiteral(item, inner)+",")
+		}
+		return "[\n" + strings.Join(lines, "\n") + "\n" + indent + "]"
+	default:
+		return marshalNoHTML(typed, "")
+	}
+}
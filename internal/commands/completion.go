@@ -0,0 +1,408 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/codegen"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/variables"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// Each script hands the words after 'saul' to the hidden __complete command
+// and falls back to file names when it has nothing to suggest
+var completionScripts = map[string]string{
+	"bash": `# bash completion for saul
+_saul() {
+    local IFS=$'\n'
+    COMPREPLY=($(saul __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _saul saul
+`,
+	"zsh": `#compdef saul
+# zsh completion for saul
+_saul() {
+    local -a candidates
+    candidates=("${(@f)$(saul __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+    if [[ -n ${candidates[1]} ]]; then
+        compadd -Q -- "${candidates[@]}"
+    else
+        _files
+    fi
+}
+compdef _saul saul
+`,
+	"fish": `# fish completion for saul
+function __saul_complete
+    set -l words (commandline -opc)
+    set -e words[1]
+    set -l candidates (saul __complete $words (commandline -ct) 2>/dev/null)
+    if test (count $candidates) -gt 0
+        printf '%s\n' $candidates
+    else
+        __fish_complete_path (commandline -ct)
+    end
+end
+complete -c saul -f -a '(__saul_complete)'
+`,
+	"powershell": `# PowerShell completion for saul
+Register-ArgumentCompleter -Native -CommandName saul -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+    $words = @($commandAst.CommandElements | Select-Object -Skip 1 | ForEach-Object { $_.ToString() })
+    # Empty arguments don't survive the trip to native commands, so a new word is flagged instead
+    if ($wordToComplete -eq '') { $words = @('--new-word') + $words }
+    saul __complete @words 2>$null | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+}
+`,
+}
+
+// ExecuteCompletionCommand prints the completion script for a shell
+func ExecuteCompletionCommand(cmd core.Command) error {
+	if cmd.Target == "" {
+		return fmt.Errorf(display.ErrCompletionShell)
+	}
+	script, exists := completionScripts[strings.ToLower(cmd.Target)]
+	if !exists {
+		shells := core.LookupCommand("completion", true).Targets
+		return fmt.Errorf(display.ErrCompletionUnknown, cmd.Target, core.DidYouMean(cmd.Target, shells))
+	}
+	fmt.Print(script)
+	return nil
+}
+
+// Complete suggests the next word for a partial command line
+// words are the arguments after 'saul', the last one being the word under the cursor
+func Complete(words []string, currentPreset string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	partial := words[len(words)-1]
+	positional, pendingFlag := splitCompletionWords(words[:len(words)-1])
+
+	var candidates []string
+	switch {
+	case pendingFlag != nil:
+		candidates = flagValueCandidates(pendingFlag, positional, currentPreset)
+		// -v keeps collecting names, but a flag can still follow
+		if pendingFlag.List && strings.HasPrefix(partial, "-") {
+			candidates = flagCandidates(positional, currentPreset)
+		}
+	case strings.HasPrefix(partial, "-"):
+		candidates = flagCandidates(positional, currentPreset)
+	default:
+		candidates = positionalCandidates(positional, currentPreset)
+	}
+	return filterCandidates(candidates, partial)
+}
+
+// splitCompletionWords separates positional words from flags and their values
+// It also returns the flag still waiting for a value at the cursor, if any
+func splitCompletionWords(words []string) ([]string, *core.FlagSpec) {
+	var positional []string
+	var pending *core.FlagSpec
+	for _, word := range words {
+		if len(word) > 1 && strings.HasPrefix(word, "-") {
+			name, _, hasValue := strings.Cut(strings.TrimLeft(word, "-"), "=")
+			pending = nil
+			if flag := core.LookupFlag(name); flag != nil && flag.TakesValue() && !hasValue {
+				pending = flag
+			}
+			continue
+		}
+		if pending != nil {
+			if !pending.List {
+				pending = nil
+			}
+			continue
+		}
+		positional = append(positional, word)
+	}
+	return positional, pending
+}
+
+// locateCommand works out which command and preset the positional words belong to
+func locateCommand(positional []string, currentPreset string) (*core.CommandSpec, string, []string) {
+	first := positional[0]
+	// rm works on the current preset unless it's 'rm preset <name...>'
+	if first == "rm" && currentPreset != "" && (len(positional) < 2 || !core.IsPresetKeyword(positional[1])) {
+		return core.LookupCommand(first, false), currentPreset, positional[1:]
+	}
+	if core.IsGlobalCommand(first) {
+		return core.LookupCommand(first, true), "", positional[1:]
+	}
+	if spec := core.LookupCommand(first, false); spec != nil && currentPreset != "" {
+		return spec, currentPreset, positional[1:]
+	}
+	if len(positional) == 1 {
+		return nil, first, nil
+	}
+	return core.LookupCommand(positional[1], false), first, positional[2:]
+}
+
+// positionalCandidates suggests commands, presets, targets or keys depending on position
+func positionalCandidates(positional []string, currentPreset string) []string {
+	if len(positional) == 0 {
+		candidates := core.CommandNames(true)
+		if currentPreset != "" {
+			candidates = append(candidates, core.CommandNames(false)...)
+		}
+		return append(candidates, presetNames()...)
+	}
+
+	spec, preset, args := locateCommand(positional, currentPreset)
+	switch {
+	case spec == nil && len(positional) == 1:
+		return core.CommandNames(false)
+	case spec == nil:
+		return nil
+	case spec.Global:
+		return globalArgCandidates(spec, args)
+	default:
+		return presetArgCandidates(spec, preset, args)
+	}
+}
+
+// globalArgCandidates completes the arguments of global commands
+func globalArgCandidates(spec *core.CommandSpec, args []string) []string {
+	switch spec.Name {
+	case "rm":
+		if len(args) == 0 {
+			return []string{"preset"}
+		}
+		return presetNames()
+	case "cp", "mv", "pushd":
+		if len(args) == 0 {
+			return presetNames()
+		}
+	case "use":
+		if len(args) == 0 {
+			return append(presetNames(), "-")
+		}
+	case "help":
+		if len(args) == 0 {
+			return append(core.CommandNames(true), core.CommandNames(false)...)
+		}
+	case "export":
+		if len(args) == 0 {
+			return spec.Targets
+		}
+		return presetNames()
+	case "config":
+		if len(args) == 0 {
+			return spec.Targets
+		}
+		if args[0] == "get" && len(args) == 1 {
+			return config.ConfigKeys
+		}
+		if args[0] == "set" {
+			// Headers are set one by one: headers.User-Agent=saul
+			var keys []string
+			for _, key := range config.ConfigKeys {
+				if key == "headers" {
+					keys = append(keys, "headers.")
+				} else {
+					keys = append(keys, key+"=")
+				}
+			}
+			return keys
+		}
+	case "import", "completion":
+		// Anything after the import format is a file, left to the shell
+		if len(args) == 0 {
+			return spec.Targets
+		}
+	}
+	return nil
+}
+
+// presetArgCandidates completes targets, keys and history numbers of preset commands
+func presetArgCandidates(spec *core.CommandSpec, preset string, args []string) []string {
+	switch spec.Name {
+	case "set":
+		if len(args) == 0 {
+			return completionTargets(spec)
+		}
+		if len(args) == 1 && args[0] == "extends" {
+			return presetNames()
+		}
+		if NormalizeTarget(args[0]) == workspace.MetaFileType {
+			var keys []string
+			for _, key := range workspace.MetaKeys {
+				keys = append(keys, key+"=")
+			}
+			return keys
+		}
+	case "get":
+		if len(args) == 0 {
+			targets := completionTargets(spec)
+			for _, number := range historyNumbers(preset) {
+				targets = append(targets, "response"+number)
+			}
+			return targets
+		}
+		if len(args) == 1 && args[0] == "history" {
+			return historyNumbers(preset)
+		}
+		if len(args) == 1 {
+			return targetKeys(preset, args[0])
+		}
+	case "edit":
+		if len(args) == 0 {
+			return completionTargets(spec)
+		}
+		if len(args) == 1 {
+			return targetKeys(preset, args[0])
+		}
+	case "rm":
+		// Every word after the target is another key to remove
+		if len(args) == 0 {
+			return spec.Targets
+		}
+		return targetKeys(preset, args[0])
+	case "export":
+		if len(args) == 0 {
+			return spec.Targets
+		}
+		return historyNumbers(preset)
+	}
+	return nil
+}
+
+// flagCandidates lists the flags the command at the cursor accepts
+func flagCandidates(positional []string, currentPreset string) []string {
+	candidates := []string{"--help"}
+	if len(positional) == 0 {
+		return candidates
+	}
+	spec, _, _ := locateCommand(positional, currentPreset)
+	if spec == nil {
+		return candidates
+	}
+	for _, flag := range spec.CommandFlags() {
+		candidates = append(candidates, "--"+flag.Name)
+	}
+	return candidates
+}
+
+// flagValueCandidates completes the value of a flag: variable names, formats or languages
+func flagValueCandidates(flag *core.FlagSpec, positional []string, currentPreset string) []string {
+	switch flag.Name {
+	case "variables":
+		preset := currentPreset
+		if len(positional) > 0 {
+			_, preset, _ = locateCommand(positional, currentPreset)
+		}
+		return variableNames(preset)
+	case "format":
+		return []string{"curl", "http"}
+	case "lang":
+		return codegen.Languages()
+	case "sort":
+		return listSortFields
+	case "on-conflict":
+		return workspace.ConflictChoices
+	}
+	// Paths, URLs and names are left to the shell
+	return nil
+}
+
+// completionTargets merges NormalizeTarget's aliases with the command's own targets
+func completionTargets(spec *core.CommandSpec) []string {
+	targets := TargetAliases()
+	for _, target := range spec.Targets {
+		if NormalizeTarget(target) == "" {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// presetNames lists existing presets, ignoring errors since completion must stay quiet
+func presetNames() []string {
+	presets, _ := workspace.ListPresets()
+	return presets
+}
+
+// historyNumbers lists the stored response numbers of a preset, most recent first
+func historyNumbers(preset string) []string {
+	if preset == "" || !workspace.PresetExists(preset) {
+		return nil
+	}
+	responses, _ := workspace.ListHistoryResponses(preset)
+	numbers := make([]string, len(responses))
+	for i := range responses {
+		numbers[i] = strconv.Itoa(i + 1)
+	}
+	return numbers
+}
+
+// targetKeys lists the dotted keys already stored in a target file
+// The file is read directly so completion never creates it
+func targetKeys(preset, target string) []string {
+	fileType := NormalizeTarget(target)
+	if preset == "" || fileType == "" || isSpecialRequestField(target) {
+		return nil
+	}
+	presetPath, err := workspace.GetPresetPath(preset)
+	if err != nil {
+		return nil
+	}
+	path := filepath.Join(presetPath, fileType+".toml")
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	handler, err := workspace.NewTomlHandler(path)
+	if err != nil {
+		return nil
+	}
+	return handler.LeafKeys()
+}
+
+// isSpecialRequestField reports targets that already name a single field
+func isSpecialRequestField(target string) bool {
+	switch strings.ToLower(target) {
+	case "url", "method", "timeout":
+		return true
+	}
+	return false
+}
+
+// variableNames lists the variables a preset uses, for -v
+func variableNames(preset string) []string {
+	if preset == "" || !workspace.PresetExists(preset) {
+		return nil
+	}
+	found, _ := variables.FindAllVariables(preset)
+	var names []string
+	for _, variable := range found {
+		if variable.Name != "" {
+			names = append(names, variable.Name)
+		} else {
+			names = append(names, variable.Key)
+		}
+	}
+	return names
+}
+
+// filterCandidates keeps the unique candidates that start with the partial word, sorted
+func filterCandidates(candidates []string, partial string) []string {
+	seen := make(map[string]bool)
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, partial) && !seen[candidate] {
+			seen[candidate] = true
+			matches = append(matches, candidate)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
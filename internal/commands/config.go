@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// ExecuteConfigCommand shows or changes the user settings: saul config get|set|edit
+func ExecuteConfigCommand(cmd core.Command) error {
+	switch cmd.Target {
+	case "", "get":
+		return configGet(cmd.Targets)
+	case "set":
+		return configSet(cmd.Targets)
+	case "edit":
+		return configEdit()
+	}
+	actions := core.LookupCommand("config", true).Targets
+	return fmt.Errorf(display.ErrConfigAction, cmd.Target, core.DidYouMean(cmd.Target, actions))
+}
+
+// configGet prints config.toml like get prints preset files, or one effective setting
+func configGet(keys []string) error {
+	settings, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		path, err := config.GetUserConfigFile()
+		if err != nil {
+			return fmt.Errorf(display.ErrDirectoryFailed)
+		}
+		// Silent when there's no file yet, like get on an empty target
+		if content, err := os.ReadFile(path); err == nil {
+			fmt.Print(string(content))
+		}
+		return nil
+	}
+
+	value, err := settings.Get(keys[0])
+	if err != nil {
+		return err
+	}
+	if value != "" {
+		display.Plain(value)
+	}
+	return nil
+}
+
+// configSet applies key=value pairs, all of them or none
+func configSet(pairs []string) error {
+	if len(pairs) == 0 {
+		return fmt.Errorf(display.ErrConfigSetFormat, "timeout")
+	}
+	current, err := config.LoadConfig()
+	if err != nil {
+		return err
+	}
+	updated := *current
+	updated.Headers = maps.Clone(current.Headers)
+
+	for _, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			return fmt.Errorf(display.ErrConfigSetFormat, pair)
+		}
+		if err := updated.Set(strings.TrimSpace(key), strings.TrimSpace(value)); err != nil {
+			return err
+		}
+	}
+	return config.SaveConfig(&updated)
+}
+
+// configEdit opens config.toml in the editor, seeding a commented template the first time
+func configEdit() error {
+	path, err := config.GetUserConfigFile()
+	if err != nil {
+		return fmt.Errorf(display.ErrDirectoryFailed)
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(path), config.DirPermissions); err != nil {
+			return fmt.Errorf(display.ErrDirectoryFailed)
+		}
+		if err := os.WriteFile(path, []byte(config.ConfigTemplate), config.FilePermissions); err != nil {
+			return fmt.Errorf(display.ErrFileSaveFailed, path)
+		}
+	}
+
+	editor := detectEditor()
+	if editor == "" {
+		return fmt.Errorf(display.ErrEditorNotFound)
+	}
+	editorCmd := exec.Command(editor, path)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return fmt.Errorf(display.ErrEditorFailed, err)
+	}
+
+	// Catch mistakes now rather than on the next call
+	_, err = config.LoadConfig()
+	return err
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// ExecuteCopyCommand handles 'saul cp' and 'saul mv': saul mv api github-api
+func ExecuteCopyCommand(cmd core.Command) error {
+	if len(cmd.Targets) != 2 {
+		return fmt.Errorf(display.ErrCopyArgs, cmd.Global)
+	}
+	source, destination := workspace.NormalizePresetName(cmd.Targets[0]), workspace.NormalizePresetName(cmd.Targets[1])
+	opts := workspace.PresetCopyOptions{
+		SkipHistory:   cmd.NoHistory,
+		SkipVariables: cmd.NoVariables,
+	}
+
+	if cmd.Global == "cp" {
+		return workspace.CopyPreset(source, destination, opts)
+	}
+
+	if err := workspace.MovePreset(source, destination, opts); err != nil {
+		return err
+	}
+	// Presets extending the old name follow it
+	if err := workspace.RenameExtendsReferences(source, destination); err != nil {
+		return err
+	}
+	// Terminals that had the old name open keep working on the new one
+	if err := core.RenamePresetInSessions(source, destination); err != nil {
+		display.Warning(fmt.Sprintf(display.WarnSessionNotMoved, err))
+	}
+	return nil
+}
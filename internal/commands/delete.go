@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/utils"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// ExecuteDeleteCommand handles 'saul rm preset api old-api'
+// Presets extending a deleted one would be left hanging, so that takes -y
+func ExecuteDeleteCommand(cmd core.Command) error {
+	if len(cmd.Targets) == 0 {
+		return fmt.Errorf(display.ErrPresetNameRequired)
+	}
+
+	var orphans []string
+	for _, presetName := range cmd.Targets {
+		dependents, err := workspace.ExtendingPresets(workspace.NormalizePresetName(presetName))
+		if err != nil {
+			return err
+		}
+		orphans = append(orphans, dependents...)
+	}
+	if len(orphans) > 0 && !cmd.Yes {
+		return fmt.Errorf(display.ErrPresetExtended, strings.Join(cmd.Targets, ", "), strings.Join(orphans, ", "))
+	}
+
+	if !cmd.Yes {
+		// Without a terminal there's nobody to answer, and silence isn't consent
+		if !utils.StdinIsTerminal() {
+			return fmt.Errorf(display.ErrConfirmNeedsYes)
+		}
+		if !utils.Confirm(fmt.Sprintf(display.PromptDeletePresets, strings.Join(cmd.Targets, ", "))) {
+			return nil
+		}
+	}
+
+	// Handle multiple targets: saul rm preset api old-api
+	// Continue processing, warn about non-existent presets
+	var warnings []string
+	for _, presetName := range cmd.Targets {
+		presetName = workspace.NormalizePresetName(presetName)
+		if err := workspace.DeletePreset(presetName); err != nil {
+			// Collect warnings for non-existent presets, continue processing
+			warnings = append(warnings, fmt.Sprintf("Warning: preset '%s' does not exist", presetName))
+			continue
+		}
+		// Terminals that had it open go back to having no preset
+		if err := core.RemovePresetFromSessions(presetName); err != nil {
+			warnings = append(warnings, fmt.Sprintf(display.WarnSessionNotMoved, err))
+		}
+	}
+	if len(orphans) > 0 {
+		warnings = append(warnings, fmt.Sprintf(display.WarnExtendsOrphaned, strings.Join(orphans, ", ")))
+	}
+
+	// Print warnings if any
+	for _, warning := range warnings {
+		display.Warning(warning)
+	}
+
+	// Silent success if at least one was deleted, or no warnings
+	return nil
+}
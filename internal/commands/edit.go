@@ -9,6 +9,7 @@ import (
 
 	"github.com/chzyer/readline"
 	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
 )
@@ -25,7 +26,7 @@ func Edit(cmd core.Command) error {
 	// Normalize target aliases
 	normalizedTarget := NormalizeTarget(cmd.Target)
 	if normalizedTarget == "" {
-		return fmt.Errorf(display.ErrInvalidTarget, cmd.Target)
+		return invalidTargetError(cmd.Target)
 	}
 	cmd.Target = normalizedTarget
 
@@ -137,7 +138,10 @@ func executeContainerEdit(cmd core.Command) error {
 
 // detectEditor finds the best available editor
 func detectEditor() string {
-	// 1. Check $EDITOR environment variable first
+	// 1. The editor from config.toml, then $EDITOR
+	if editor := config.Current().Editor; editor != "" {
+		return editor
+	}
 	if editor := os.Getenv("EDITOR"); editor != "" {
 		return editor
 	}
@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// Export writes presets in formats other tools understand
+// saul <preset> export har [response numbers...]  - one preset, selected responses
+// saul export har <preset...>                     - whole history of several presets
+// saul export http [preset...]                    - requests as a .http file (all presets by default)
+// saul export [bundle] <preset...> -o x.tar.gz     - presets packed to share with someone else's saul
+func Export(cmd core.Command) error {
+	if cmd.Target == "" {
+		return fmt.Errorf(display.ErrExportFormatRequired)
+	}
+
+	// Presets named straight after export, bound for a .tar.gz, are a bundle
+	format := strings.ToLower(cmd.Target)
+	if format != "har" && format != "http" && format != "bundle" && cmd.Global != "" && isBundleFile(cmd.Output) {
+		cmd.Targets = append([]string{cmd.Target}, cmd.Targets...)
+		format = "bundle"
+	}
+
+	switch format {
+	case "har":
+		selections, err := harSelections(cmd)
+		if err != nil {
+			return err
+		}
+		data, err := workspace.ExportHAR(selections, !cmd.NoRedact)
+		if err != nil {
+			return err
+		}
+		return writeExport(data, cmd.Output)
+	case "http":
+		presets, err := httpExportPresets(cmd)
+		if err != nil {
+			return err
+		}
+		data, err := workspace.ExportToHTTP(presets)
+		if err != nil {
+			return err
+		}
+		return writeExport([]byte(strings.TrimSuffix(data, "\n")), cmd.Output)
+	case "bundle":
+		presets, err := httpExportPresets(cmd)
+		if err != nil {
+			return err
+		}
+		if presets, err = workspace.ExpandPresetNames(presets); err != nil {
+			return err
+		}
+		data, err := workspace.ExportBundle(presets, workspace.BundleExportOptions{
+			WithHistory: cmd.WithHistory,
+			KeepSecrets: cmd.NoRedact,
+		})
+		if err != nil {
+			return err
+		}
+		return writeBundle(data, cmd.Output)
+	default:
+		return fmt.Errorf(display.ErrExportFormatUnknown, cmd.Target)
+	}
+}
+
+// harSelections maps command arguments onto presets and response numbers
+func harSelections(cmd core.Command) ([]workspace.HARExportSelection, error) {
+	// Global form: every argument is a preset
+	if cmd.Global != "" {
+		if len(cmd.Targets) == 0 {
+			return nil, fmt.Errorf(display.ErrPresetNameRequired)
+		}
+		var selections []workspace.HARExportSelection
+		for _, preset := range cmd.Targets {
+			selections = append(selections, workspace.HARExportSelection{Preset: preset})
+		}
+		return selections, nil
+	}
+
+	// Preset form: arguments are response numbers
+	selection := workspace.HARExportSelection{Preset: cmd.Preset}
+	for _, arg := range cmd.Targets {
+		number, err := ParseResponseNumber(strings.TrimPrefix(strings.ToLower(arg), "response"), cmd.Preset)
+		if err != nil {
+			return nil, err
+		}
+		selection.Numbers = append(selection.Numbers, number)
+	}
+	return []workspace.HARExportSelection{selection}, nil
+}
+
+// httpExportPresets picks the presets for a .http export or a bundle, the global form
+// without arguments exports the whole collection
+func httpExportPresets(cmd core.Command) ([]string, error) {
+	if cmd.Global == "" {
+		return []string{cmd.Preset}, nil
+	}
+	if len(cmd.Targets) > 0 {
+		return cmd.Targets, nil
+	}
+	presets, err := workspace.ListPresets()
+	if err != nil {
+		return nil, err
+	}
+	if len(presets) == 0 {
+		return nil, fmt.Errorf(display.ErrPresetNameRequired)
+	}
+	return presets, nil
+}
+
+// isBundleFile reports whether an output file name asks for a bundle
+func isBundleFile(output string) bool {
+	output = strings.ToLower(output)
+	return strings.HasSuffix(output, ".tar.gz") || strings.HasSuffix(output, ".tgz")
+}
+
+// writeBundle writes a bundle to the -o file, or to stdout when that isn't a terminal
+func writeBundle(data []byte, output string) error {
+	if output == "" {
+		if term.IsTerminal(int(os.Stdout.Fd())) {
+			return fmt.Errorf(display.ErrBundleTerminal)
+		}
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(output, data, config.FilePermissions); err != nil {
+		return fmt.Errorf(display.ErrFileSaveFailed, output)
+	}
+	return nil
+}
+
+// writeExport prints exported data or writes it to the -o file
+func writeExport(data []byte, output string) error {
+	if output == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+	if err := os.WriteFile(output, append(data, '\n'), config.FilePermissions); err != nil {
+		return fmt.Errorf(display.ErrFileSaveFailed, output)
+	}
+	return nil
+}
@@ -3,12 +3,16 @@ package commands
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/http"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/variables"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/codegen"
 )
 
 
@@ -28,6 +32,20 @@ func Get(cmd core.Command) error {
 		return nil
 	}
 
+	// Whole preset in another tool's format: get --format curl|http
+	if cmd.Format != "" && cmd.Target == "" {
+		return getFormatted(cmd)
+	}
+	// Code snippet: get --lang go|python|js|httpie|powershell
+	if cmd.Lang != "" && cmd.Target == "" {
+		return getCode(cmd)
+	}
+
+	// Effective values after extends and collections: get --resolved [target]
+	if cmd.Resolved {
+		return getResolved(cmd)
+	}
+
 	if cmd.Target == "" {
 		return fmt.Errorf(display.ErrTargetRequired)
 	}
@@ -62,7 +80,7 @@ func Get(cmd core.Command) error {
 	// Normalize target aliases
 	normalizedTarget := NormalizeTarget(cmd.Target)
 	if normalizedTarget == "" {
-		return fmt.Errorf(display.ErrInvalidTarget, cmd.Target)
+		return invalidTargetError(cmd.Target)
 	}
 	cmd.Target = normalizedTarget
 
@@ -122,6 +140,75 @@ func Get(cmd core.Command) error {
 }
 
 
+// getResolved prints the effective values of a target, or of every inherited one,
+// each followed by the preset or collection/ that set it
+func getResolved(cmd core.Command) error {
+	targets := []string{"request", "headers", "query", "variables"}
+	if cmd.Target != "" {
+		target := NormalizeTarget(cmd.Target)
+		if target == "" {
+			return invalidTargetError(cmd.Target)
+		}
+		targets = []string{target}
+	}
+
+	// url/method/timeout are parsed as request fields, the rest as keys of the target
+	filter := ""
+	if len(cmd.KeyValuePairs) > 0 {
+		filter = cmd.KeyValuePairs[0].Key
+	}
+
+	for _, target := range targets {
+		handler, sources, err := workspace.ResolvePresetFile(cmd.Preset, target)
+		if err != nil {
+			return err
+		}
+
+		var keys []string
+		for _, key := range handler.LeafKeys() {
+			if filter == "" || key == filter || strings.HasPrefix(key, filter+".") {
+				keys = append(keys, key)
+			}
+		}
+		if filter != "" && len(keys) == 0 {
+			return fmt.Errorf(display.ErrKeyNotFound, filter, target)
+		}
+
+		lines := make([]string, len(keys))
+		width := 0
+		for i, key := range keys {
+			lines[i] = fmt.Sprintf("%s = %s", key, formatResolvedValue(handler.Get(key)))
+			width = max(width, len(lines[i]))
+		}
+		for i, key := range keys {
+			lines[i] = fmt.Sprintf("%-*s  # %s", width, lines[i], sources[key])
+		}
+
+		if len(targets) == 1 {
+			fmt.Println(strings.Join(lines, "\n"))
+		} else if len(lines) > 0 {
+			display.Plain(display.FormatSimpleSection(target, "  "+strings.Join(lines, "\n  ")))
+		}
+	}
+	return nil
+}
+
+// formatResolvedValue renders a value the way it reads in a TOML file
+func formatResolvedValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = formatResolvedValue(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
 // getHistory handles history listing (LIST operation only)
 func getHistory(cmd core.Command) error {
 	// History command only lists responses - no specific response access
@@ -137,7 +224,7 @@ func getResponse(cmd core.Command) error {
 		return fmt.Errorf("no history found for preset '%s'", cmd.Preset)
 	}
 
-	return DisplayHistoryResponse(cmd.Preset, number, cmd.RawOutput)
+	return DisplayHistoryResponse(cmd.Preset, number, rawResponse(cmd))
 }
 
 // getResponseWithField handles response field extraction (e.g., response1 body, response2 headers)
@@ -153,7 +240,7 @@ func getResponseWithField(cmd core.Command) error {
 	// Check if field is specified
 	if len(cmd.KeyValuePairs) == 0 || cmd.KeyValuePairs[0].Key == "" {
 		// No field specified - show whole response (single-line support)
-		return DisplayHistoryResponse(cmd.Preset, number, cmd.RawOutput)
+		return DisplayHistoryResponse(cmd.Preset, number, rawResponse(cmd))
 	}
 
 	fieldName := strings.ToLower(cmd.KeyValuePairs[0].Key)
@@ -165,7 +252,12 @@ func getResponseWithField(cmd core.Command) error {
 	}
 
 	// Extract and display the requested field
-	return displayResponseField(response, fieldName, cmd.RawOutput, cmd.Preset)
+	return displayResponseField(response, fieldName, rawResponse(cmd), cmd.Preset)
+}
+
+// rawResponse reports whether responses print raw: --raw, or output = "raw" in config.toml
+func rawResponse(cmd core.Command) bool {
+	return cmd.RawOutput || config.Current().OutputMode() == config.OutputRaw
 }
 
 // displayResponseField extracts and displays a specific field from response
@@ -269,11 +361,70 @@ func getResponseFieldMostRecent(cmd core.Command) error {
 	}
 
 	// Extract and display the requested field
-	return displayResponseField(response, fieldName, cmd.RawOutput, cmd.Preset)
+	return displayResponseField(response, fieldName, rawResponse(cmd), cmd.Preset)
 }
 
+// getFormatted prints the whole preset as a curl command or .http request
+func getFormatted(cmd core.Command) error {
+	var output string
+	var err error
 
+	switch strings.ToLower(cmd.Format) {
+	case "curl":
+		output, err = workspace.ExportToCurl(cmd.Preset)
+	case "http":
+		output, err = workspace.ExportToHTTP([]string{cmd.Preset})
+	default:
+		return fmt.Errorf(display.ErrGetFormatUnknown, cmd.Format)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Print(output)
+	return nil
+}
 
+// getCode prints the preset as a snippet for another language or tool
+// Variables stay as {@name}/{?name} placeholders unless --substitute resolves them like call does
+func getCode(cmd core.Command) error {
+	generator, ok := codegen.Lookup(cmd.Lang)
+	if !ok {
+		return fmt.Errorf(display.ErrLangUnknown, cmd.Lang, strings.Join(codegen.Languages(), ", "))
+	}
 
+	requestHandler, err := workspace.LoadResolvedPresetFile(cmd.Preset, "request")
+	if err != nil {
+		return err
+	}
+	headersHandler, err := workspace.LoadResolvedPresetFile(cmd.Preset, "headers")
+	if err != nil {
+		return err
+	}
+	bodyHandler, err := workspace.LoadPresetFile(cmd.Preset, "body")
+	if err != nil {
+		return err
+	}
+	queryHandler, err := workspace.LoadResolvedPresetFile(cmd.Preset, "query")
+	if err != nil {
+		return err
+	}
 
+	if cmd.Substitute {
+		substitutions, err := variables.PromptForVariables(cmd.Preset, false)
+		if err != nil {
+			return fmt.Errorf(display.ErrVariableLoadFailed)
+		}
+		for _, handler := range []*workspace.TomlHandler{requestHandler, headersHandler, bodyHandler, queryHandler} {
+			if err := variables.SubstituteVariables(handler, substitutions); err != nil {
+				return fmt.Errorf(display.ErrVariableLoadFailed)
+			}
+		}
+	}
 
+	request, err := codegen.FromHandlers(requestHandler, headersHandler, bodyHandler, queryHandler)
+	if err != nil {
+		return err
+	}
+	fmt.Print(generator.Generate(request))
+	return nil
+}
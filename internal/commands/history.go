@@ -80,13 +80,17 @@ func ListHistoryResponses(preset string, rawOutput bool) error {
 		relativeTime := FormatRelativeTime(response.Timestamp)
 
 		// Clean tabular format: "  1  POST /api/users    201  0.234s  2m ago"
-		display.Plain(fmt.Sprintf("  %-2d %-4s %-20s %-3s %-8s %s",
+		line := fmt.Sprintf("  %-2d %-4s %-20s %-3s %-8s %s",
 			displayIndex,
 			response.Method,
 			path,
 			statusCode,
 			response.Duration,
-			relativeTime))
+			relativeTime)
+		if len(response.Violations) > 0 {
+			line += fmt.Sprintf("  ! %d spec violation(s)", len(response.Violations))
+		}
+		display.Plain(line)
 	}
 
 	return nil
@@ -127,6 +131,9 @@ func DisplayHistoryResponse(preset string, number int, rawOutput bool) error {
 			content,
 			fmt.Sprintf("%s • %s", response.Status, FormatRelativeTime(response.Timestamp)))
 		display.Plain(formatted)
+		for _, violation := range response.Violations {
+			display.Warning("  ! " + violation)
+		}
 	}
 
 	return nil
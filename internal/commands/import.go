@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/utils"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// Import creates presets from files exported by other tools: saul import <format> <file>
+func Import(cmd core.Command) error {
+	if cmd.Target == "" {
+		return fmt.Errorf(display.ErrImportFormatRequired)
+	}
+	if len(cmd.Targets) == 0 {
+		return fmt.Errorf(display.ErrImportFileRequired)
+	}
+
+	var result *workspace.ImportResult
+	var err error
+
+	switch strings.ToLower(cmd.Target) {
+	case "curl":
+		result, err = workspace.ImportCurlFile(cmd.Targets[0], workspace.CurlImportOptions{
+			Prefix: cmd.Into,
+		})
+	case "har":
+		result, err = workspace.ImportHARFile(cmd.Targets[0], workspace.HARImportOptions{
+			Filter:      cmd.Filter,
+			Prefix:      cmd.Into,
+			WithHistory: cmd.WithHistory,
+		})
+	case "postman":
+		result, err = workspace.ImportPostmanCollection(cmd.Targets[0], workspace.PostmanImportOptions{
+			EnvironmentFile: cmd.Env,
+			Prefix:          cmd.Into,
+		})
+	case "insomnia":
+		result, err = workspace.ImportInsomniaExport(cmd.Targets[0], workspace.InsomniaImportOptions{
+			Environment: cmd.Env,
+			Prefix:      cmd.Into,
+		})
+	case "bruno":
+		result, err = workspace.ImportBrunoCollection(cmd.Targets[0], workspace.BrunoImportOptions{
+			Environment: cmd.Env,
+			Prefix:      cmd.Into,
+		})
+	case "openapi", "swagger":
+		result, err = workspace.ImportOpenAPISpec(cmd.Targets[0], workspace.OpenAPIImportOptions{
+			Tag:    cmd.Tag,
+			Prefix: cmd.Into,
+		})
+	case "http":
+		result, err = workspace.ImportHTTPFile(cmd.Targets[0], workspace.HTTPImportOptions{
+			Prefix: cmd.Into,
+		})
+	case "bundle":
+		resolve, resolveErr := conflictResolver(cmd.OnConflict)
+		if resolveErr != nil {
+			return resolveErr
+		}
+		result, err = workspace.ImportBundle(cmd.Targets[0], workspace.BundleImportOptions{
+			Prefix:  cmd.Into,
+			Resolve: resolve,
+		})
+	default:
+		return fmt.Errorf(display.ErrImportFormatUnknown, cmd.Target)
+	}
+
+	if result != nil {
+		printImportResult(result, err == nil)
+	}
+	return err
+}
+
+// conflictResolver answers bundle name collisions: --on-conflict for all of them,
+// else asking each time on a terminal, else refusing rather than guessing
+func conflictResolver(choice string) (func(preset string) (string, error), error) {
+	choices := workspace.ConflictChoices
+	if choice != "" {
+		choice = strings.ToLower(choice)
+		if !slices.Contains(choices, choice) {
+			return nil, fmt.Errorf(display.ErrConflictChoice, choice, strings.Join(choices, ", "), core.DidYouMean(choice, choices))
+		}
+		return func(string) (string, error) { return choice, nil }, nil
+	}
+	if !utils.StdinIsTerminal() {
+		return func(preset string) (string, error) {
+			return "", fmt.Errorf(display.ErrConflictNeedsChoice, preset, strings.Join(choices, "|"))
+		}, nil
+	}
+	return func(preset string) (string, error) {
+		return utils.Choose(fmt.Sprintf(display.PromptBundleConflict, preset), choices), nil
+	}, nil
+}
+
+// printImportResult lists created presets and everything that couldn't be translated
+// The summary is left out when the import stopped partway
+func printImportResult(result *workspace.ImportResult, finished bool) {
+	for _, preset := range result.Presets {
+		display.Plain("  + " + preset)
+	}
+	for _, warning := range result.Warnings {
+		display.Warning("  ! " + warning)
+	}
+	if finished {
+		display.Info(fmt.Sprintf(display.InfoImportSummary, len(result.Presets)))
+	}
+}
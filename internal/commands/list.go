@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// listSortFields are the columns saul ls --sort understands
+var listSortFields = []string{"name", "method", "url", "vars", "status", "called"}
+
+// PresetSummary is one line of saul ls, and one object of saul ls --json
+type PresetSummary struct {
+	Name      string   `json:"name"`
+	Method    string   `json:"method,omitempty"`
+	URL       string   `json:"url,omitempty"`
+	Variables int      `json:"variables"`
+	Status    string   `json:"status,omitempty"`
+	CalledAt  string   `json:"called_at,omitempty"` // RFC3339, from the latest stored response
+	Tags      []string `json:"tags,omitempty"`
+	Broken    string   `json:"broken,omitempty"` // Why the inherited request couldn't be resolved
+
+	Description string `json:"description,omitempty"`
+	Owner       string `json:"owner,omitempty"`
+	Docs        string `json:"docs,omitempty"`
+}
+
+// ListPresets is the native saul ls: every preset with what it calls and how that went
+func ListPresets(cmd core.Command) error {
+	summaries, err := FindPresets(cmd.Targets, cmd.Tag, cmd.Sort)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case cmd.JSON:
+		data, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			return err
+		}
+		display.Plain(string(data))
+	case cmd.RawOutput:
+		for _, summary := range summaries {
+			display.Plain(summary.Name)
+		}
+	default:
+		printSummaries(summaries)
+	}
+	return nil
+}
+
+// FindPresets summarizes the presets matching the filters, sorted by a listSortFields column
+// Text terms match on name, URL, tags and description, a tag keeps the presets carrying it
+func FindPresets(terms []string, tag, sortField string) ([]PresetSummary, error) {
+	field := strings.ToLower(sortField)
+	if field == "" {
+		field = "name"
+	}
+	if !slices.Contains(listSortFields, field) {
+		return nil, fmt.Errorf(display.ErrListSortField, sortField, strings.Join(listSortFields, ", "), core.DidYouMean(sortField, listSortFields))
+	}
+
+	names, err := workspace.ListCollection("")
+	if err != nil {
+		return nil, err
+	}
+	summaries := []PresetSummary{}
+	for _, name := range names {
+		summary := summarizePreset(name)
+		if summary.matches(terms, tag) {
+			summaries = append(summaries, summary)
+		}
+	}
+	sortSummaries(summaries, field)
+	return summaries, nil
+}
+
+// summarizePreset gathers a preset's line, leaving out whatever can't be read
+func summarizePreset(preset string) PresetSummary {
+	meta := workspace.LoadPresetMeta(preset)
+	summary := PresetSummary{Name: preset, Tags: meta.Tags, Description: meta.Description, Owner: meta.Owner, Docs: meta.Docs}
+	request, err := workspace.LoadResolvedPresetFile(preset, "request")
+	if err != nil {
+		// A broken extends still leaves what the preset sets itself
+		summary.Broken = err.Error()
+		request = workspace.ReadPresetFile(preset, "request")
+	}
+	if request != nil {
+		summary.Method = strings.ToUpper(request.GetAsString("method"))
+		summary.URL = request.GetAsString("url")
+		if summary.Method == "" && summary.URL != "" {
+			summary.Method = "GET" // What call sends when no method is set
+		}
+	}
+	summary.Variables = len(filterCandidates(variableNames(preset), ""))
+	if responses, _ := workspace.ListHistoryResponses(preset); len(responses) > 0 {
+		latest := responses[len(responses)-1]
+		summary.Status, summary.CalledAt = latest.Status, latest.Timestamp
+	}
+	return summary
+}
+
+// matches keeps presets where every text term shows up in the name, URL, tags or description,
+// and that carry the tag
+func (s PresetSummary) matches(terms []string, tag string) bool {
+	if tag != "" && !slices.ContainsFunc(s.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+		return false
+	}
+	haystack := strings.ToLower(strings.Join(append([]string{s.Name, s.URL, s.Description}, s.Tags...), " "))
+	for _, term := range terms {
+		if !strings.Contains(haystack, strings.ToLower(term)) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortSummaries orders the listing, name breaking ties; called puts the latest first
+func sortSummaries(summaries []PresetSummary, field string) {
+	key := func(s PresetSummary) string {
+		switch field {
+		case "method":
+			return s.Method
+		case "url":
+			return s.URL
+		case "vars":
+			return fmt.Sprintf("%06d", s.Variables)
+		case "status":
+			return ExtractStatusCode(s.Status)
+		}
+		return ""
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if field == "called" && summaries[i].CalledAt != summaries[j].CalledAt {
+			return summaries[i].CalledAt > summaries[j].CalledAt
+		}
+		if a, b := key(summaries[i]), key(summaries[j]); a != b {
+			return a < b
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+}
+
+// printSummaries lines the listing up in columns: "api  GET  https://...  2 vars  200  3m ago  #admin"
+func printSummaries(summaries []PresetSummary) {
+	nameWidth, methodWidth, urlWidth := 0, 0, 0
+	for _, s := range summaries {
+		nameWidth = max(nameWidth, len(s.Name))
+		methodWidth = max(methodWidth, len(orDash(s.Method)))
+		urlWidth = max(urlWidth, len(orDash(s.URL)))
+	}
+
+	for _, s := range summaries {
+		called := "-"
+		if s.CalledAt != "" {
+			called = ExtractStatusCode(s.Status) + "  " + FormatRelativeTime(s.CalledAt)
+		}
+		vars := strconv.Itoa(s.Variables) + " vars"
+		if s.Variables == 1 {
+			vars = "1 var"
+		}
+		line := fmt.Sprintf("%-*s  %-*s  %-*s  %-7s  %-12s", nameWidth, s.Name, methodWidth, orDash(s.Method), urlWidth, orDash(s.URL), vars, called)
+		if s.Broken != "" {
+			line += " [broken]"
+		}
+		for _, tag := range s.Tags {
+			line += " #" + tag
+		}
+		display.Plain(strings.TrimRight(line, " "))
+	}
+}
+
+// orDash stands in for a value a preset doesn't have yet
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// ExecuteNavigationCommand handles use, pushd, popd and recent, which move around this terminal's session
+func ExecuteNavigationCommand(cmd core.Command, sessionManager *core.SessionManager) error {
+	switch cmd.Global {
+	case "use":
+		return usePreset(cmd.Target, sessionManager)
+	case "pushd":
+		if cmd.Target != "" {
+			preset, err := existingPreset(cmd.Target)
+			if err != nil {
+				return err
+			}
+			return sessionManager.PushPreset(preset)
+		}
+		return sessionManager.PushPreset("")
+	case "popd":
+		_, err := sessionManager.PopPreset()
+		return err
+	case "recent":
+		return listRecent(sessionManager, cmd.RawOutput)
+	}
+	return fmt.Errorf(display.ErrUnknownCommand, cmd.Global, "")
+}
+
+// usePreset switches presets: saul use api, saul use - for the previous one
+// Without a name it prints the current preset followed by the stack, like dirs
+func usePreset(target string, sessionManager *core.SessionManager) error {
+	switch target {
+	case "":
+		if sessionManager.HasCurrentPreset() {
+			display.Plain(strings.Join(append([]string{sessionManager.GetCurrentPreset()}, sessionManager.Stack()...), " "))
+		}
+		return nil
+	case "-":
+		target = sessionManager.GetPreviousPreset()
+		if target == "" {
+			return fmt.Errorf(display.ErrNoPreviousPreset)
+		}
+	}
+	preset, err := existingPreset(target)
+	if err != nil {
+		return err
+	}
+	return sessionManager.SetCurrentPreset(preset)
+}
+
+// existingPreset normalizes a preset name and makes sure it's there
+// Unlike 'saul <preset>', switching never creates a preset from a typo
+func existingPreset(name string) (string, error) {
+	name = workspace.NormalizePresetName(name)
+	if err := workspace.ValidatePresetName(name); err != nil {
+		return "", err
+	}
+	if !workspace.PresetExists(name) || workspace.IsCollection(name) {
+		return "", fmt.Errorf(display.ErrPresetNotFound, name)
+	}
+	return name, nil
+}
+
+// listRecent shows the presets this terminal used, with how their last call went
+func listRecent(sessionManager *core.SessionManager, rawOutput bool) error {
+	var recent []core.RecentPreset
+	width := 0
+	for _, entry := range sessionManager.RecentPresets() {
+		// Presets deleted since are left out
+		if workspace.PresetExists(entry.Name) {
+			recent = append(recent, entry)
+			width = max(width, len(entry.Name))
+		}
+	}
+
+	for _, entry := range recent {
+		if rawOutput {
+			display.Plain(entry.Name)
+			continue
+		}
+		marker := " "
+		if entry.Name == sessionManager.GetCurrentPreset() {
+			marker = "*"
+		}
+		status, when := "-", "used "+FormatRelativeTime(entry.UsedAt.Format(time.RFC3339))
+		if !entry.CalledAt.IsZero() {
+			status, when = entry.Status, "called "+FormatRelativeTime(entry.CalledAt.Format(time.RFC3339))
+		}
+		display.Plain(fmt.Sprintf("%s %-*s  %-24s %s", marker, width, entry.Name, status, when))
+	}
+	return nil
+}
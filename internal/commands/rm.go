@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// Rm removes keys from a target file: saul api rm body user.email 'tags.*'
+// Every key must exist before anything is removed, and tables left empty go with them
+func Rm(cmd core.Command) error {
+	if cmd.Preset == "" {
+		return fmt.Errorf(display.ErrPresetNameRequired)
+	}
+	if cmd.Target == "" {
+		return fmt.Errorf(display.ErrTargetRequired)
+	}
+
+	target := NormalizeTarget(cmd.Target)
+	if target == "" {
+		// Muscle memory from 'saul rm <preset>'
+		if workspace.PresetExists(cmd.Target) {
+			return fmt.Errorf(display.ErrRmPresetForm, cmd.Target, cmd.Target)
+		}
+		return invalidTargetError(cmd.Target)
+	}
+	if target == "request" || target == "filters" {
+		return fmt.Errorf(display.ErrRmTargetUnsupported, cmd.Target)
+	}
+	if len(cmd.KeyValuePairs) == 0 {
+		return fmt.Errorf(display.ErrRmKeyRequired, target)
+	}
+
+	handler, err := workspace.LoadPresetFile(cmd.Preset, target)
+	if err != nil {
+		return fmt.Errorf(display.ErrFileLoadFailed, target+".toml")
+	}
+
+	for _, kv := range cmd.KeyValuePairs {
+		if removed := handler.DeletePath(kv.Key); len(removed) == 0 {
+			return fmt.Errorf(display.ErrKeyNotFound, kv.Key, target)
+		}
+	}
+
+	if err := workspace.SavePresetFile(cmd.Preset, target, handler); err != nil {
+		return fmt.Errorf(display.ErrFileSaveFailed, target+".toml")
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// searchFields are the places saul search looks, a hit in the name counting most
+var searchFields = []struct {
+	name   string
+	weight int
+}{
+	{"name", 8}, {"tags", 6}, {"description", 5}, {"url", 4}, {"body", 3}, {"owner", 2}, {"docs", 1}, {"history", 1},
+}
+
+// SearchMatch is a preset saul search found, and where
+type SearchMatch struct {
+	PresetSummary
+	Score  int      `json:"score"`
+	Fields []string `json:"matched"` // Best field first
+}
+
+// Search finds presets by what they are and what they send: saul search refund
+func Search(cmd core.Command) error {
+	matches, err := SearchPresets(cmd.Targets, cmd.SearchHistory)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case cmd.JSON:
+		data, err := json.MarshalIndent(matches, "", "  ")
+		if err != nil {
+			return err
+		}
+		display.Plain(string(data))
+	case cmd.RawOutput:
+		for _, match := range matches {
+			display.Plain(match.Name)
+		}
+	default:
+		width := 0
+		for _, match := range matches {
+			width = max(width, len(match.Name))
+		}
+		for _, match := range matches {
+			line := fmt.Sprintf("%-*s  %s %s", width, match.Name, orDash(match.Method), orDash(match.URL))
+			if match.Description != "" {
+				line += "  " + match.Description
+			}
+			display.Plain(line + "  [" + strings.Join(match.Fields, ", ") + "]")
+		}
+	}
+	return nil
+}
+
+// SearchPresets ranks the presets where every term shows up in a searchFields field
+// Stored response bodies are only searched when withHistory is set, they can be big
+func SearchPresets(terms []string, withHistory bool) ([]SearchMatch, error) {
+	if len(terms) == 0 {
+		return nil, fmt.Errorf(display.ErrSearchTerms)
+	}
+	names, err := workspace.ListCollection("")
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []SearchMatch{}
+	for _, name := range names {
+		summary := summarizePreset(name)
+		texts := searchTexts(summary, withHistory)
+		match := SearchMatch{PresetSummary: summary}
+		found := make(map[string]bool)
+		for _, term := range terms {
+			term = strings.ToLower(term)
+			hit := false
+			for _, field := range searchFields {
+				if strings.Contains(texts[field.name], term) {
+					match.Score += field.weight
+					found[field.name], hit = true, true
+				}
+			}
+			if !hit {
+				match.Score = 0
+				break
+			}
+		}
+		if match.Score == 0 {
+			continue
+		}
+		// The exact name beats everything that merely mentions it
+		if strings.EqualFold(name, strings.Join(terms, " ")) || strings.EqualFold(name[strings.LastIndex(name, "/")+1:], strings.Join(terms, " ")) {
+			match.Score += 10
+		}
+		for _, field := range searchFields {
+			if found[field.name] {
+				match.Fields = append(match.Fields, field.name)
+			}
+		}
+		matches = append(matches, match)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Name < matches[j].Name
+	})
+	return matches, nil
+}
+
+// searchTexts gathers the lowercased text of every searchFields field of a preset
+func searchTexts(summary PresetSummary, withHistory bool) map[string]string {
+	texts := map[string]string{
+		"name":        summary.Name,
+		"tags":        strings.Join(summary.Tags, " "),
+		"description": summary.Description,
+		"url":         summary.URL,
+		"body":        strings.Join(targetKeys(summary.Name, "body"), " "),
+		"owner":       summary.Owner,
+		"docs":        summary.Docs,
+	}
+	if withHistory {
+		responses, _ := workspace.ListHistoryResponses(summary.Name)
+		var bodies []string
+		for _, response := range responses {
+			if text, isText := response.Body.(string); isText {
+				bodies = append(bodies, text)
+			} else if data, err := json.Marshal(response.Body); err == nil {
+				bodies = append(bodies, string(data))
+			}
+		}
+		texts["history"] = strings.Join(bodies, "\n")
+	}
+	for field, text := range texts {
+		texts[field] = strings.ToLower(text)
+	}
+	return texts
+}
@@ -5,22 +5,37 @@ package commands
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/utils"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/variables"
 )
 
 // Set handles set operations for TOML files
 func Set(cmd core.Command) error {
-	// Handle --raw flag for curl import via editor
+	// Handle --raw flag for curl import (file, clipboard, stdin or editor)
 	if cmd.RawOutput {
 		if cmd.Preset == "" {
 			return fmt.Errorf(display.ErrPresetNameRequired)
 		}
-		return workspace.ImportCurlViaEditor(cmd.Preset)
+		curlCmd, err := readRawCurl(cmd)
+		if err != nil {
+			return err
+		}
+		result, err := workspace.ImportCurlString(cmd.Preset, curlCmd)
+		if result != nil {
+			for _, warning := range result.Warnings {
+				display.Warning("  ! " + warning)
+			}
+		}
+		return err
 	}
 
 	if cmd.Preset == "" {
@@ -36,7 +51,7 @@ func Set(cmd core.Command) error {
 	// Normalize target aliases for better UX
 	normalizedTarget := NormalizeTarget(cmd.Target)
 	if normalizedTarget == "" {
-		return fmt.Errorf(display.ErrInvalidTarget, cmd.Target)
+		return invalidTargetError(cmd.Target)
 	}
 
 	// Use normalized target for file operations
@@ -49,7 +64,11 @@ func Set(cmd core.Command) error {
 	}
 
 	// Special handling for filters target - store values as array
-	if cmd.Target == "filters" {
+	if cmd.Target == workspace.MetaFileType {
+		if err := setMeta(handler, cmd.KeyValuePairs); err != nil {
+			return err
+		}
+	} else if cmd.Target == "filters" {
 		var fields []string
 		for _, kvp := range cmd.KeyValuePairs {
 			fields = append(fields, kvp.Value)
@@ -63,6 +82,11 @@ func Set(cmd core.Command) error {
 				if err := ValidateRequestField(kvp.Key, kvp.Value); err != nil {
 					return err
 				}
+				if strings.ToLower(kvp.Key) == workspace.ExtendsKey {
+					if err := workspace.ValidateExtends(cmd.Preset, kvp.Value); err != nil {
+						return err
+					}
+				}
 			}
 
 			// Detect if value is a variable
@@ -88,6 +112,14 @@ func Set(cmd core.Command) error {
 					} else if strings.ToLower(kvp.Key) == "history" {
 						// Map "history" to "history_count" for storage
 						keyToStore = "history_count"
+					} else if strings.ToLower(kvp.Key) == workspace.ExtendsKey {
+						keyToStore = workspace.ExtendsKey
+						valueToStore = workspace.NormalizePresetName(kvp.Value)
+					} else if strings.ToLower(kvp.Key) == workspace.OpenAPISpecKey {
+						// Store the spec location absolute so calls work from any directory
+						if absolute, err := filepath.Abs(kvp.Value); err == nil {
+							valueToStore = absolute
+						}
 					}
 				}
 
@@ -105,4 +137,60 @@ func Set(cmd core.Command) error {
 
 	// Silent success - Unix philosophy
 	return nil
-}
\ No newline at end of file
+}
+
+// setMeta stores what a preset is about, kept as typed: no variables, no type inference
+// tags takes a list (tags=refund,billing) and an empty value removes the key
+func setMeta(handler *workspace.TomlHandler, pairs []core.KeyValuePair) error {
+	for _, kvp := range pairs {
+		key := strings.ToLower(kvp.Key)
+		if !slices.Contains(workspace.MetaKeys, key) {
+			return fmt.Errorf(display.ErrMetaKeyUnknown, kvp.Key, strings.Join(workspace.MetaKeys, ", "), core.DidYouMean(kvp.Key, workspace.MetaKeys))
+		}
+		switch {
+		case kvp.Value == "":
+			handler.Delete(key)
+		case key == "tags":
+			handler.Set(key, workspace.SplitTags(kvp.Value))
+		default:
+			handler.Set(key, kvp.Value)
+		}
+	}
+	return nil
+}
+
+// readRawCurl gets the curl command for set --raw: --from-file, --clipboard, piped stdin, else $EDITOR
+func readRawCurl(cmd core.Command) (string, error) {
+	var content string
+	switch {
+	case cmd.FromFile != "":
+		data, err := os.ReadFile(cmd.FromFile)
+		if err != nil {
+			return "", fmt.Errorf(display.ErrFileLoadFailed, cmd.FromFile)
+		}
+		content = string(data)
+	case cmd.Clipboard:
+		text, err := utils.ReadClipboard()
+		if err != nil {
+			return "", fmt.Errorf(display.ErrClipboardUnavailable, err)
+		}
+		content = text
+	case utils.StdinIsPiped():
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf(display.ErrInputRead)
+		}
+		content = string(data)
+	default:
+		text, err := workspace.ReadCurlViaEditor(cmd.Preset)
+		if err != nil {
+			return "", err
+		}
+		content = text
+	}
+
+	if strings.TrimSpace(content) == "" {
+		return "", fmt.Errorf(display.ErrEmptyCurlCommand)
+	}
+	return content, nil
+}
@@ -1,26 +1,52 @@
 package commands
 
 import (
+	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
 )
 
+// targetAliases maps every accepted target spelling to its canonical file name
+var targetAliases = map[string]string{
+	"body":      "body",
+	"headers":   "headers",
+	"header":    "headers",
+	"query":     "query",
+	"queries":   "query",
+	"request":   "request",
+	"req":       "request",
+	"url":       "request",
+	"variables": "variables",
+	"vars":      "variables",
+	"var":       "variables",
+	"filters":   "filters",
+	"filter":    "filters",
+	"meta":      "meta",
+	"metadata":  "meta",
+}
+
+// targetNames are the canonical targets, used to suggest one for a typo
+var targetNames = []string{"body", "headers", "query", "request", "variables", "filters", "meta"}
 
 // NormalizeTarget converts target aliases to canonical names
 func NormalizeTarget(target string) string {
-	switch strings.ToLower(target) {
-	case "body":
-		return "body"
-	case "headers", "header":
-		return "headers"
-	case "query", "queries":
-		return "query"
-	case "request", "req", "url":
-		return "request"
-	case "variables", "vars", "var":
-		return "variables"
-	case "filters", "filter":
-		return "filters"
-	default:
-		return ""
+	return targetAliases[strings.ToLower(target)]
+}
+
+// TargetAliases returns every spelling NormalizeTarget accepts, sorted
+func TargetAliases() []string {
+	aliases := make([]string, 0, len(targetAliases))
+	for alias := range targetAliases {
+		aliases = append(aliases, alias)
 	}
-}
\ No newline at end of file
+	sort.Strings(aliases)
+	return aliases
+}
+
+// invalidTargetError explains an unknown target and suggests the closest real one
+func invalidTargetError(target string) error {
+	return fmt.Errorf(display.ErrInvalidTarget, target, core.DidYouMean(target, targetNames))
+}
@@ -5,6 +5,7 @@ package commands
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
@@ -23,6 +24,8 @@ func ValidateRequestField(key, value string) error {
 		return validateTimeout(value)
 	case "history", "history_count":
 		return validateHistoryCount(value)
+	case "openapi":
+		return validateSpecFile(value)
 	default:
 		return nil
 	}
@@ -80,6 +83,14 @@ func validateHistoryCount(count string) error {
 	return nil
 }
 
+// validateSpecFile checks that a linked OpenAPI document exists
+func validateSpecFile(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf(display.ErrFileLoadFailed, path)
+	}
+	return nil
+}
+
 // InferValueType converts string values to appropriate Go types for TOML
 func InferValueType(value string) interface{} {
 	return utils.InferValueType(value)
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// storeMessages explains each way the store can be picked
+var storeMessages = map[string]string{
+	config.StoreSaulHome: display.InfoStoreSaulHome,
+	config.StoreProject:  display.InfoStoreProject,
+	config.StoreXDG:      display.InfoStoreXDG,
+	config.StoreGlobal:   display.InfoStoreGlobal,
+}
+
+// Where shows the active preset store, --raw prints only its path for scripts
+func Where(cmd core.Command) error {
+	store, err := config.ResolveStore()
+	if err != nil {
+		return fmt.Errorf(display.ErrDirectoryFailed)
+	}
+	if cmd.RawOutput {
+		display.Plain(store.Path)
+		return nil
+	}
+	display.Info(fmt.Sprintf(storeMessages[store.Source], store.Path))
+	return nil
+}
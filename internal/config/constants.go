@@ -9,6 +9,11 @@ const (
 	ParentDirPath   = ".config"
 	AppDirName      = "saul"
 	PresetsDirName  = "presets"
+	ProjectDirName  = ".saul" // Project-local store, found by walking up like .git
+
+	// Environment overrides for the store location
+	SaulHomeEnv      = "SAUL_HOME"       // Store directory, wins over everything
+	XDGConfigHomeEnv = "XDG_CONFIG_HOME" // Replaces ~/.config
 
 	// Default values
 	DefaultTimeoutSeconds = 30
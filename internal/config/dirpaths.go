@@ -5,13 +5,72 @@ import (
 	"path/filepath"
 )
 
-// config/paths.go (new file)
-func GetConfigPath() (string, error) {
+// Where a store came from, as reported by saul where
+const (
+	StoreSaulHome = "SAUL_HOME"
+	StoreProject  = "project"
+	StoreXDG      = "XDG_CONFIG_HOME"
+	StoreGlobal   = "global"
+)
+
+// Store is the directory holding presets and sessions, and the reason it was picked
+type Store struct {
+	Path   string
+	Source string
+}
+
+// ResolveStore picks the store in order: $SAUL_HOME, a .saul directory in the
+// working directory or any parent, $XDG_CONFIG_HOME/saul, then ~/.config/saul
+func ResolveStore() (Store, error) {
+	if os.Getenv(SaulHomeEnv) == "" {
+		if projectPath := findProjectStore(); projectPath != "" {
+			return Store{Path: projectPath, Source: StoreProject}, nil
+		}
+	}
+	return UserStore()
+}
+
+// UserStore is the store outside any project, where config.toml lives too
+func UserStore() (Store, error) {
+	if saulHome := os.Getenv(SaulHomeEnv); saulHome != "" {
+		return Store{Path: saulHome, Source: StoreSaulHome}, nil
+	}
+	if xdgHome := os.Getenv(XDGConfigHomeEnv); xdgHome != "" {
+		return Store{Path: filepath.Join(xdgHome, AppDirName), Source: StoreXDG}, nil
+	}
 	home, err := os.UserHomeDir() // Cross-platform
+	if err != nil {
+		return Store{}, err
+	}
+	return Store{Path: filepath.Join(home, ParentDirPath, AppDirName), Source: StoreGlobal}, nil
+}
+
+// findProjectStore walks up from the working directory looking for a .saul directory
+func findProjectStore() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	for {
+		candidate := filepath.Join(dir, ProjectDirName)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// GetConfigPath returns the active store directory
+func GetConfigPath() (string, error) {
+	store, err := ResolveStore()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, ParentDirPath, AppDirName), nil
+	return store.Path, nil
 }
 
 func GetPresetsPath() (string, error) {
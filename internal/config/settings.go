@@ -3,27 +3,284 @@
 // with fallback mechanisms for containerized and production environments.
 package config
 
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
-// LoadConfig loads configuration using hardcoded constants
-// This is temporary until toml-vars-letsgooo library is ready
-func LoadConfig() *Config {
-	return &Config{
-		ParentDirPath:  ParentDirPath,
-		AppDirName:     AppDirName,
-		PresetsDirName: PresetsDirName,
-		TimeoutSeconds: DefaultTimeoutSeconds,
-		MaxRetries:     DefaultMaxRetries,
-		HTTPMethod:     DefaultHTTPMethod,
+	lib "github.com/pelletier/go-toml"
+
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// Output modes for responses
+const (
+	OutputTOML = "toml"
+	OutputJSON = "json"
+	OutputRaw  = "raw"
+)
+
+// Color modes
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// ConfigFileName is the user settings file, kept in the user store
+const ConfigFileName = "config.toml"
+
+// Config holds the user settings from config.toml
+// Zero values mean unset: presets and flags override what is set, built-ins fill the rest
+type Config struct {
+	Timeout int               `toml:"timeout,omitempty"`
+	History int               `toml:"history,omitempty"`
+	Output  string            `toml:"output,omitempty"`
+	Color   string            `toml:"color,omitempty"`
+	Editor  string            `toml:"editor,omitempty"`
+	Proxy   string            `toml:"proxy,omitempty"`
+	Redact  []string          `toml:"redact,omitempty"`
+	Headers map[string]string `toml:"headers,omitempty"`
+}
+
+// ConfigKeys lists the settings saul config understands, headers.<Name> sets one header
+var ConfigKeys = []string{"timeout", "history", "output", "color", "editor", "proxy", "redact", "headers"}
+
+// ConfigTemplate seeds config.toml for saul config edit
+const ConfigTemplate = `# Saul's user settings - presets override these, flags override both
+
+# timeout = 30             # Seconds to wait when a preset doesn't set its own
+# history = 5              # Responses kept by presets created from now on
+# output = "toml"          # How responses are shown: toml, json or raw
+# color = "auto"           # auto, always or never
+# editor = "nvim"          # Wins over $EDITOR
+# proxy = "http://127.0.0.1:8080"
+# redact = ["x-tenant"]    # Key names redacted in exports, on top of the built-in list
+
+# [headers]                # Sent with every request, presets override them
+# User-Agent = "saul"
+`
+
+// loadedConfig caches config.toml for the file it was read from
+var loadedConfig struct {
+	path    string
+	modTime time.Time
+	config  *Config
+}
+
+// GetUserConfigFile returns the path of config.toml
+func GetUserConfigFile() (string, error) {
+	store, err := UserStore()
+	if err != nil {
+		return "", err
 	}
+	return filepath.Join(store.Path, ConfigFileName), nil
 }
 
-type Config struct {
-	ParentDirPath  string
-	AppDirName     string
-	PresetsDirName string
-	TimeoutSeconds int
-	MaxRetries     int
-	HTTPMethod     string
+// LoadConfig reads config.toml, a missing file is an empty config
+func LoadConfig() (*Config, error) {
+	path, err := GetUserConfigFile()
+	if err != nil {
+		return &Config{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return &Config{}, nil
+	}
+	if loadedConfig.config != nil && loadedConfig.path == path && loadedConfig.modTime.Equal(info.ModTime()) {
+		return loadedConfig.config, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return &Config{}, fmt.Errorf(display.ErrConfigInvalid, path, err)
+	}
+	config := &Config{}
+	if err := lib.Unmarshal(data, config); err != nil {
+		return &Config{}, fmt.Errorf(display.ErrConfigInvalid, path, err)
+	}
+	if err := config.validate(); err != nil {
+		return &Config{}, fmt.Errorf(display.ErrConfigInvalid, path, err)
+	}
+
+	loadedConfig.path, loadedConfig.modTime, loadedConfig.config = path, info.ModTime(), config
+	return config, nil
+}
+
+// Current returns the user settings, empty when config.toml is missing or broken
+// main reports a broken file once, everything else just falls back to the built-ins
+func Current() *Config {
+	config, _ := LoadConfig()
+	return config
+}
+
+// SaveConfig writes config.toml
+func SaveConfig(config *Config) error {
+	path, err := GetUserConfigFile()
+	if err != nil {
+		return err
+	}
+	data, err := lib.Marshal(*config)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), DirPermissions); err != nil {
+		return fmt.Errorf(display.ErrDirectoryFailed)
+	}
+	if err := os.WriteFile(path, data, FilePermissions); err != nil {
+		return fmt.Errorf(display.ErrFileSaveFailed, path)
+	}
+	loadedConfig.config = nil
+	return nil
+}
+
+// TimeoutSeconds is the request timeout for presets without their own
+func (c *Config) TimeoutSeconds() int {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return DefaultTimeoutSeconds
+}
+
+// OutputMode is how responses are shown when no flag says otherwise
+func (c *Config) OutputMode() string {
+	if c.Output != "" {
+		return c.Output
+	}
+	return OutputTOML
+}
+
+// ColorMode is whether output gets colored
+func (c *Config) ColorMode() string {
+	if c.Color != "" {
+		return c.Color
+	}
+	return ColorAuto
+}
+
+// Get returns a setting as text, built-in defaults included
+func (c *Config) Get(key string) (string, error) {
+	if name, isHeader := strings.CutPrefix(key, "headers."); isHeader {
+		return c.Headers[name], nil
+	}
+	switch strings.ToLower(key) {
+	case "timeout":
+		return strconv.Itoa(c.TimeoutSeconds()), nil
+	case "history":
+		return strconv.Itoa(c.History), nil
+	case "output":
+		return c.OutputMode(), nil
+	case "color":
+		return c.ColorMode(), nil
+	case "editor":
+		return c.Editor, nil
+	case "proxy":
+		return c.Proxy, nil
+	case "redact":
+		return strings.Join(c.Redact, "\n"), nil
+	case "headers":
+		var lines []string
+		for name, value := range c.Headers {
+			lines = append(lines, name+": "+value)
+		}
+		sort.Strings(lines)
+		return strings.Join(lines, "\n"), nil
+	}
+	return "", unknownKeyError(key)
+}
+
+// Set changes one setting from its text form, an empty value unsets it
+func (c *Config) Set(key, value string) error {
+	if name, isHeader := strings.CutPrefix(key, "headers."); isHeader && name != "" {
+		if value == "" {
+			delete(c.Headers, name)
+			return nil
+		}
+		if c.Headers == nil {
+			c.Headers = make(map[string]string)
+		}
+		c.Headers[name] = value
+		return nil
+	}
+
+	switch strings.ToLower(key) {
+	case "timeout", "history":
+		number := 0
+		if value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf(display.ErrConfigNotNumber, key, value)
+			}
+			number = parsed
+		}
+		if strings.ToLower(key) == "timeout" {
+			c.Timeout = number
+		} else {
+			c.History = number
+		}
+	case "output":
+		c.Output = strings.ToLower(value)
+	case "color":
+		c.Color = strings.ToLower(value)
+	case "editor":
+		c.Editor = value
+	case "proxy":
+		c.Proxy = value
+	case "redact":
+		// redact=x-tenant,ssn or redact=[x-tenant,ssn]
+		c.Redact = nil
+		for _, name := range strings.Split(strings.Trim(value, "[]"), ",") {
+			if name = strings.Trim(strings.TrimSpace(name), `"'`); name != "" {
+				c.Redact = append(c.Redact, name)
+			}
+		}
+	default:
+		return unknownKeyError(key)
+	}
+	return c.validate()
 }
 
+// validate checks the settings that only take certain values
+func (c *Config) validate() error {
+	if c.Timeout < 0 {
+		return fmt.Errorf(display.ErrConfigNotNumber, "timeout", strconv.Itoa(c.Timeout))
+	}
+	if c.History < 0 || c.History > 100 {
+		return fmt.Errorf(display.ErrConfigHistory, c.History)
+	}
+	if err := checkChoice("output", c.Output, OutputTOML, OutputJSON, OutputRaw); err != nil {
+		return err
+	}
+	if err := checkChoice("color", c.Color, ColorAuto, ColorAlways, ColorNever); err != nil {
+		return err
+	}
+	if c.Proxy != "" {
+		if parsed, err := url.Parse(c.Proxy); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf(display.ErrConfigProxy, c.Proxy)
+		}
+	}
+	return nil
+}
 
+// checkChoice accepts an unset value or one of the allowed ones
+func checkChoice(key, value string, allowed ...string) error {
+	if value == "" {
+		return nil
+	}
+	for _, choice := range allowed {
+		if value == choice {
+			return nil
+		}
+	}
+	return fmt.Errorf(display.ErrConfigChoice, key, value, strings.Join(allowed, ", "))
+}
+
+// unknownKeyError lists the settings there are
+func unknownKeyError(key string) error {
+	return fmt.Errorf(display.ErrConfigKeyUnknown, key, strings.Join(ConfigKeys, ", "))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
 )
@@ -21,6 +22,25 @@ func IsSystemCommand(command string) bool {
 	return false
 }
 
+// IsNativeListing reports whether saul answers an ls itself
+// Only flags the native listing knows keep it there: saul ls -la is the system's ls
+func IsNativeListing(args []string) bool {
+	if args[0] != "ls" {
+		return false
+	}
+	spec := LookupCommand("ls", true)
+	for _, arg := range args[1:] {
+		if !isFlagArg(arg) {
+			continue
+		}
+		name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if flag := LookupFlag(name); flag == nil || !spec.Accepts(flag.Name) {
+			return false
+		}
+	}
+	return true
+}
+
 // DelegateToSystem executes a system command in the presets directory
 func DelegateToSystem(command string, args []string) error {
 	// Set working directory to presets folder
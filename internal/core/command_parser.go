@@ -27,6 +27,32 @@ type Command struct {
 	ResponseFormat   string   // --headers-only, --body-only, --status-only
 	DryRun          bool     // --dry-run
 	Call            bool     // --call
+	WithHistory     bool     // --with-history (import)
+	NoRedact        bool     // --no-redact (export)
+	Substitute      bool     // --substitute (get --lang)
+	Help            bool     // --help/-h
+	Clipboard       bool     // --clipboard (set --raw)
+	Yes             bool     // -y/--yes (rm preset)
+	NoHistory       bool     // --no-history (cp, mv)
+	NoVariables     bool     // --no-variables (cp, mv)
+	Resolved        bool     // --resolved (get)
+	JSON            bool     // --json (ls, search)
+	SearchHistory   bool     // --history (search)
+
+	// Value flags
+	Port       string // --port 8888 (record)
+	Into       string // --into/--prefix preset name prefix (record, import)
+	Upstream   string // --upstream https://api.example.com (record)
+	Filter     string // --filter regex (import)
+	Output     string // -o/--output file (export)
+	Env        string // --env environment file or name (import postman/insomnia/bruno)
+	Tag        string // --tag name (import openapi, ls)
+	Sort       string // --sort field (ls)
+	Format     string // --format curl|http (get)
+	Lang       string // --lang go|python|js|httpie|powershell (get)
+	FromFile   string // --from-file path (set --raw)
+	Save       string // --save preset (one-shot requests)
+	OnConflict string // --on-conflict rename|overwrite|skip (import bundle)
 }
 
 type KeyValuePair struct {
@@ -42,37 +68,91 @@ func ParseCommand(args []string) (Command, error) {
 	}
 
 	// Check for system commands FIRST - skip flag parsing for them
-	if IsSystemCommand(args[0]) {
+	// Plain ls is saul's own listing, ls with system flags like -la still goes to the system
+	if IsSystemCommand(args[0]) && !IsNativeListing(args) {
 		cmd.Preset = args[0]  // Store system command in Preset field for delegation
 		return cmd, nil
 	}
 
-	// Parse flags and filter them out of args (only for non-system commands)
-	filteredArgs, err := parseFlags(args, &cmd)
+	// Split flags from positional args and apply them; they're checked against the command below
+	args, flags, err := splitFlags(args)
 	if err != nil {
 		return cmd, err
 	}
-	args = filteredArgs
+	for _, flag := range flags {
+		flag.spec.set(&cmd, flag.value)
+	}
+
+	// --help anywhere shows help for the command it's attached to
+	if cmd.Help || len(args) == 0 {
+		cmd.Global = "help"
+		cmd.Target = helpTopic(args)
+		return cmd, nil
+	}
+
+	cmd, err = parsePositional(args, cmd)
+	if err != nil {
+		return cmd, err
+	}
+	return cmd, checkFlags(cmd, flags)
+}
 
+// parsePositional fills in the command, target and values once flags are out of the way
+func parsePositional(args []string, cmd Command) (Command, error) {
 	switch args[0] {
 	case "rm":
+		// Deleting presets takes the explicit form: saul rm preset api old-api
+		// Any other rm is field removal in the current preset, injected before parsing
+		if len(args) < 2 || !IsPresetKeyword(args[1]) {
+			return cmd, fmt.Errorf(display.ErrNoCurrentPreset)
+		}
+		cmd.Global = args[0]
+		cmd.Targets = args[2:]
+		return cmd, nil
+	case "record":
+		cmd.Global = args[0]
+		return cmd, nil
+	case "ls", "search":
+		// Everything after ls filters the listing, everything after search is what to look for
+		cmd.Global = args[0]
+		cmd.Targets = args[1:]
+		return cmd, nil
+	case "cp", "mv":
+		// saul cp <preset> <new-name>
+		cmd.Global = args[0]
+		cmd.Targets = args[1:]
+		return cmd, nil
+	case "import", "export", "config":
+		// saul import <format> <file...>, saul export <format> <preset...>, saul config set <key=value...>
 		cmd.Global = args[0]
 		if len(args) >= 2 {
-			// Handle multiple targets for rm: saul rm preset1 preset2 preset3
-			cmd.Targets = args[1:]
-			// Keep single target for backward compatibility
-			cmd.Preset = args[1]
+			cmd.Target = args[1]
+			cmd.Targets = args[2:]
 		}
 		return cmd, nil
-	case "version", "help", "update":
+	case "version", "help", "update", "completion", "where", "use", "pushd", "popd", "recent":
 		cmd.Global = args[0]
 		if len(args) >= 2 {
-			cmd.Preset = args[1]
+			cmd.Target = args[1]
 		}
 		return cmd, nil
 	}
 
-	cmd.Preset = args[0]
+	// One-shot HTTPie-style request: saul POST https://api/x name=john, saul https://api/x
+	if isOneShotRequest(args) {
+		cmd.Global = "request"
+		if !strings.Contains(args[0], "://") {
+			cmd.Target = args[0]
+			args = args[1:]
+		}
+		cmd.Targets = args
+		// --save makes the new preset the current one
+		cmd.Preset = cmd.Save
+		return cmd, nil
+	}
+
+	// Collections are written like paths, github/issues/ is github/issues
+	cmd.Preset = strings.Trim(args[0], "/")
 
 	if len(args) > 1 {
 		cmd.Command = args[1]
@@ -117,6 +197,26 @@ func ParseCommand(args []string) (Command, error) {
 		return cmd, nil
 	}
 
+	// Handle rm command (saul preset rm target key [key...])
+	if cmd.Command == "rm" {
+		if len(args) > 2 {
+			cmd.Target = args[2]
+			for _, key := range args[3:] {
+				cmd.KeyValuePairs = append(cmd.KeyValuePairs, KeyValuePair{Key: key})
+			}
+		}
+		return cmd, nil
+	}
+
+	// Handle export command (saul preset export har [response numbers...])
+	if cmd.Command == "export" {
+		if len(args) > 2 {
+			cmd.Target = args[2]
+			cmd.Targets = args[3:]
+		}
+		return cmd, nil
+	}
+
 	// Handle edit command (same syntax as check: edit target [key])
 	if cmd.Command == "edit" {
 		if len(args) > 2 {
@@ -166,9 +266,23 @@ func ParseCommand(args []string) (Command, error) {
 	return cmd, nil
 }
 
+// isOneShotRequest checks for an uppercase method followed by a URL, or a bare URL
+// Methods must be uppercase so presets named get/post keep working
+func isOneShotRequest(args []string) bool {
+	if strings.Contains(args[0], "://") {
+		return true
+	}
+	for _, method := range HTTPMethods {
+		if args[0] == method {
+			return len(args) > 1
+		}
+	}
+	return false
+}
+
 // isSpecialRequestCommand checks if a command is a special request command (no = syntax)
 func isSpecialRequestCommand(command string) bool {
-	specialCommands := []string{"url", "method", "timeout", "history"}
+	specialCommands := []string{"url", "method", "timeout", "history", "openapi", "operation", "extends"}
 	command = strings.ToLower(command)
 
 	for _, special := range specialCommands {
@@ -203,63 +317,121 @@ func parseSpaceSeparatedKeyValues(args []string) ([]KeyValuePair, error) {
 	return pairs, nil
 }
 
-// parseFlags extracts flags from args and sets them in cmd, returning filtered args
-func parseFlags(args []string, cmd *Command) ([]string, error) {
-	var filteredArgs []string
-	skip := 0
+// parsedFlag is a flag found on the command line, checked against the command once that's known
+type parsedFlag struct {
+	spec  *FlagSpec
+	typed string // As written, for error messages
+	value string
+}
 
-	for i, arg := range args {
-		if skip > 0 {
-			skip--
+// splitFlags separates flags from positional args using the flag registry
+// Accepts --flag value, --flag=value, -o value and -o=value
+func splitFlags(args []string) ([]string, []parsedFlag, error) {
+	var positional []string
+	var flags []parsedFlag
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !isFlagArg(arg) {
+			positional = append(positional, arg)
 			continue
 		}
 
-		if strings.HasPrefix(arg, "--") {
-			// Handle long flags
-			switch arg {
-			case "--raw":
-				cmd.RawOutput = true
-			case "--headers-only":
-				cmd.ResponseFormat = "headers-only"
-			case "--body-only":
-				cmd.ResponseFormat = "body-only"
-			case "--status-only":
-				cmd.ResponseFormat = "status-only"
-			case "--dry-run":
-				cmd.DryRun = true
-			case "--call":
-				cmd.Call = true
-			default:
-				return nil, fmt.Errorf("unknown flag: %s", arg)
+		typed, value, hasValue := strings.Cut(arg, "=")
+		var spec *FlagSpec
+		if name, isLong := strings.CutPrefix(typed, "--"); isLong {
+			if spec = LookupFlag(name); spec != nil && spec.Short == name {
+				spec = nil // --o isn't -o
+			}
+		} else if len(typed) == 2 {
+			if spec = LookupFlag(typed[1:]); spec != nil && spec.Short != typed[1:] {
+				spec = nil // -raw isn't --raw
 			}
-		} else if strings.HasPrefix(arg, "-") && len(arg) > 1 && !strings.HasPrefix(arg, "--") {
-			// Handle short flags
-			flagPart := arg[1:] // Remove leading -
-			if flagPart == "v" {
-				// -v flag: collect all following non-flag arguments as variable names
-				varCount := 0
-				for j := i + 1; j < len(args); j++ {
-					if strings.HasPrefix(args[j], "-") {
-						break // Stop at next flag
-					}
-					cmd.VariableFlags = append(cmd.VariableFlags, args[j])
-					varCount++
+		}
+		if spec == nil {
+			return nil, nil, fmt.Errorf(display.ErrUnknownFlag, typed, DidYouMean(typed, flagNames()))
+		}
+
+		switch {
+		case spec.List:
+			// -v a b c only collects once the command is known, so it never swallows the command itself
+			if !hasValue && commandSeen(positional) {
+				var values []string
+				for i+1 < len(args) && !isFlagArg(args[i+1]) {
+					i++
+					values = append(values, args[i])
 				}
-				skip = varCount // Skip these args in main loop
-				// If no variables specified, empty slice signals "all variables"
-				if len(cmd.VariableFlags) == 0 {
-					cmd.VariableFlags = []string{}
+				value = strings.Join(values, ",")
+			}
+		case spec.TakesValue():
+			if !hasValue {
+				if i+1 >= len(args) || isFlagArg(args[i+1]) {
+					return nil, nil, fmt.Errorf(display.ErrFlagNeedsValue, typed, spec.Value)
 				}
-			} else {
-				return nil, fmt.Errorf("unknown flag: %s", arg)
+				i++
+				value = args[i]
 			}
-		} else {
-			// Not a flag, keep in filtered args
-			filteredArgs = append(filteredArgs, arg)
+		case hasValue:
+			return nil, nil, fmt.Errorf(display.ErrFlagTakesNoValue, typed)
 		}
+
+		flags = append(flags, parsedFlag{spec: spec, typed: typed, value: value})
 	}
 
-	return filteredArgs, nil
+	return positional, flags, nil
 }
 
+// isFlagArg tells flags from values; a lone "-" means stdin and stays positional
+func isFlagArg(arg string) bool {
+	return len(arg) > 1 && arg[0] == '-'
+}
+
+// commandSeen reports whether the positional args so far already include the command word
+func commandSeen(positional []string) bool {
+	return len(positional) >= 2 || (len(positional) == 1 && IsGlobalCommand(positional[0]))
+}
 
+// helpTopic picks the command a --help flag was attached to: saul set --help, saul api call --help
+func helpTopic(args []string) string {
+	for _, arg := range args {
+		if arg == "help" {
+			continue
+		}
+		if LookupCommand(arg, true) != nil || LookupCommand(arg, false) != nil || isOneShotRequest([]string{arg, ""}) {
+			return arg
+		}
+	}
+	return ""
+}
+
+// checkFlags rejects unknown commands and flags used where they don't apply
+func checkFlags(cmd Command, flags []parsedFlag) error {
+	name := cmd.Global
+	spec := LookupCommand(cmd.Global, true)
+	if cmd.Global == "" {
+		name = cmd.Command
+		spec = LookupCommand(cmd.Command, false)
+		if spec == nil && cmd.Command != "" {
+			return fmt.Errorf(display.ErrUnknownCommand, cmd.Command, DidYouMean(cmd.Command, CommandNames(false)))
+		}
+	}
+
+	for _, flag := range flags {
+		if spec == nil {
+			// Bare "saul <preset>" only switches presets
+			if flag.spec.Name != "help" {
+				return fmt.Errorf(display.ErrFlagNotAllowed, flag.typed, cmd.Preset, "saul help")
+			}
+			continue
+		}
+		if !spec.Accepts(flag.spec.Name) {
+			return fmt.Errorf(display.ErrFlagNotAllowed, flag.typed, name, "saul help "+name)
+		}
+	}
+	return nil
+}
+
+// IsPresetKeyword reports whether rm is followed by the explicit preset form
+func IsPresetKeyword(arg string) bool {
+	return arg == "preset" || arg == "presets"
+}
@@ -0,0 +1,129 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCommandFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		check   func(t *testing.T, cmd Command)
+		wantErr string
+	}{
+		{
+			name: "Value flag with equals",
+			args: []string{"api", "get", "--lang=python"},
+			check: func(t *testing.T, cmd Command) {
+				if cmd.Lang != "python" {
+					t.Errorf("Lang = %q, want python", cmd.Lang)
+				}
+			},
+		},
+		{
+			name: "Short value flag",
+			args: []string{"export", "har", "-o", "bug.har", "--no-redact"},
+			check: func(t *testing.T, cmd Command) {
+				if cmd.Output != "bug.har" || !cmd.NoRedact {
+					t.Errorf("Output = %q, NoRedact = %v", cmd.Output, cmd.NoRedact)
+				}
+			},
+		},
+		{
+			name: "Flag alias",
+			args: []string{"api", "get", "response", "--header-only"},
+			check: func(t *testing.T, cmd Command) {
+				if cmd.ResponseFormat != "headers-only" {
+					t.Errorf("ResponseFormat = %q, want headers-only", cmd.ResponseFormat)
+				}
+			},
+		},
+		{
+			name: "Variables after the command collect names",
+			args: []string{"api", "call", "-v", "token", "name", "--dry-run"},
+			check: func(t *testing.T, cmd Command) {
+				if !reflect.DeepEqual(cmd.VariableFlags, []string{"token", "name"}) || !cmd.DryRun {
+					t.Errorf("VariableFlags = %v, DryRun = %v", cmd.VariableFlags, cmd.DryRun)
+				}
+			},
+		},
+		{
+			name: "Variables with equals split on commas",
+			args: []string{"api", "call", "-v=token,name"},
+			check: func(t *testing.T, cmd Command) {
+				if !reflect.DeepEqual(cmd.VariableFlags, []string{"token", "name"}) {
+					t.Errorf("VariableFlags = %v", cmd.VariableFlags)
+				}
+			},
+		},
+		{
+			name: "Bare variables flag prompts for all",
+			args: []string{"api", "call", "-v"},
+			check: func(t *testing.T, cmd Command) {
+				if cmd.VariableFlags == nil || len(cmd.VariableFlags) != 0 {
+					t.Errorf("VariableFlags = %#v, want empty non-nil", cmd.VariableFlags)
+				}
+			},
+		},
+		{
+			name: "Help flag turns into a help topic",
+			args: []string{"api", "set", "--help"},
+			check: func(t *testing.T, cmd Command) {
+				if cmd.Global != "help" || cmd.Target != "set" {
+					t.Errorf("Global = %q, Target = %q", cmd.Global, cmd.Target)
+				}
+			},
+		},
+		{
+			name: "Call after set keeps the response flags",
+			args: []string{"api", "set", "body", "x=1", "--call", "--body-only"},
+			check: func(t *testing.T, cmd Command) {
+				if !cmd.Call || cmd.ResponseFormat != "body-only" {
+					t.Errorf("Call = %v, ResponseFormat = %q", cmd.Call, cmd.ResponseFormat)
+				}
+			},
+		},
+		{
+			name: "Call after edit takes a dry run",
+			args: []string{"api", "edit", "url", "--call", "--dry-run"},
+			check: func(t *testing.T, cmd Command) {
+				if !cmd.Call || !cmd.DryRun {
+					t.Errorf("Call = %v, DryRun = %v", cmd.Call, cmd.DryRun)
+				}
+			},
+		},
+		{name: "Unknown flag suggests", args: []string{"api", "call", "--dry-rn"}, wantErr: "'--dry-run'"},
+		{name: "Flag not allowed", args: []string{"api", "set", "body", "a=1", "--lang", "go"}, wantErr: "saul help set"},
+		{name: "Missing value", args: []string{"api", "get", "--lang"}, wantErr: "needs a value"},
+		{name: "Switch with value", args: []string{"api", "call", "--raw=yes"}, wantErr: "switch"},
+		{name: "Unknown command suggests", args: []string{"api", "sett", "body", "a=1"}, wantErr: "'set'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := ParseCommand(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one mentioning %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCommand error: %v", err)
+			}
+			tt.check(t, cmd)
+		})
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	candidates := []string{"body", "headers", "query"}
+	if got := DidYouMean("hedaers", candidates); !strings.Contains(got, "headers") {
+		t.Errorf("DidYouMean(hedaers) = %q", got)
+	}
+	if got := DidYouMean("completely-different", candidates); got != "" {
+		t.Errorf("DidYouMean(completely-different) = %q, want no suggestion", got)
+	}
+}
@@ -1,95 +1,467 @@
 package core
 
 import (
+	"encoding/base64"
 	"fmt"
 	"net/url"
-	"regexp"
 	"strings"
 )
 
 type CurlRequest struct {
 	Method  string
 	URL     string
-	BaseURL string
+	BaseURL string // URL without query string (repeated query keys stay in it)
 	Query   map[string]string
 	Headers map[string]string
 	Body    string
+	Form    []CurlFormField // -F fields, in order
+
+	// Options that were understood but can't be reproduced by a preset
+	Warnings []string
 }
 
-func ParseCurl(curlCmd string) (*CurlRequest, error) {
-	curlCmd = strings.TrimSpace(curlCmd)
+// CurlFormField is one -F/--form field; IsFile marks name=@path uploads
+type CurlFormField struct {
+	Name   string
+	Value  string
+	IsFile bool
+}
+
+// curlOption describes how one curl option affects the request
+// Options with neither apply nor warn are accepted and ignored (output, verbosity...)
+type curlOption struct {
+	value bool // Option takes an argument
+	apply func(p *curlParser, arg string)
+	warn  string
+}
+
+// curlShortOptions maps curl's single-letter flags to their long names
+var curlShortOptions = map[byte]string{
+	'X': "request", 'H': "header", 'd': "data", 'F': "form", 'u': "user",
+	'b': "cookie", 'A': "user-agent", 'e': "referer", 'G': "get", 'I': "head",
+	'r': "range", 'k': "insecure", 'L': "location", 's': "silent", 'S': "show-error",
+	'i': "include", 'v': "verbose", 'f': "fail", 'o': "output", 'O': "remote-name",
+	'w': "write-out", 'm': "max-time", 'x': "proxy", 'E': "cert", 'c': "cookie-jar",
+	'T': "upload-file", 'K': "config", 'n': "netrc", 'N': "no-buffer", '#': "progress-bar",
+	'0': "http1.0", '4': "ipv4", '6': "ipv6", 'g': "globoff", 'Z': "parallel", 'q': "disable",
+}
+
+var curlOptions = map[string]curlOption{
+	"request":        {value: true, apply: func(p *curlParser, arg string) { p.method = strings.ToUpper(arg) }},
+	"header":         {value: true, apply: (*curlParser).addHeaderLine},
+	"url":            {value: true, apply: (*curlParser).addURL},
+	"data":           {value: true, apply: (*curlParser).addData},
+	"data-ascii":     {value: true, apply: (*curlParser).addData},
+	"data-binary":    {value: true, apply: (*curlParser).addData},
+	"data-raw":       {value: true, apply: func(p *curlParser, arg string) { p.data = append(p.data, arg) }},
+	"data-urlencode": {value: true, apply: (*curlParser).addURLEncodedData},
+	"json":           {value: true, apply: (*curlParser).addJSON},
+	"form":           {value: true, apply: (*curlParser).addForm},
+	"form-string":    {value: true, apply: func(p *curlParser, arg string) { p.addFormField(arg, false) }},
+	"user":           {value: true, apply: (*curlParser).addBasicAuth},
+	"oauth2-bearer":  {value: true, apply: func(p *curlParser, arg string) { p.setHeader("Authorization", "Bearer "+arg) }},
+	"cookie":         {value: true, apply: (*curlParser).addCookie},
+	"user-agent":     {value: true, apply: func(p *curlParser, arg string) { p.setHeader("User-Agent", arg) }},
+	"referer":        {value: true, apply: func(p *curlParser, arg string) { p.setHeader("Referer", arg) }},
+	"range":          {value: true, apply: func(p *curlParser, arg string) { p.setHeader("Range", "bytes="+arg) }},
+	"get":            {apply: func(p *curlParser, _ string) { p.dataAsQuery = true }},
+	"head":           {apply: func(p *curlParser, _ string) { p.head = true }},
+
+	// Accepted without changing the request
+	"location": {}, "compressed": {}, "silent": {}, "show-error": {}, "include": {},
+	"verbose": {}, "fail": {}, "fail-with-body": {}, "no-buffer": {}, "progress-bar": {},
+	"globoff": {}, "http1.0": {}, "http1.1": {}, "http2": {}, "http2-prior-knowledge": {},
+	"http3": {}, "ipv4": {}, "ipv6": {}, "raw": {}, "path-as-is": {}, "disable": {},
+	"remote-name": {}, "location-trusted": {}, "no-progress-meter": {}, "parallel": {},
+	"output":      {value: true},
+	"write-out":   {value: true},
+	"stderr":      {value: true},
+	"trace":       {value: true},
+	"trace-ascii": {value: true},
+	"max-redirs":  {value: true},
+
+	// Understood, but a preset has nowhere to keep them
+	"insecure":        {warn: "saul always verifies TLS certificates"},
+	"max-time":        {value: true, warn: "use 'saul set request timeout=N' instead"},
+	"connect-timeout": {value: true, warn: "use 'saul set request timeout=N' instead"},
+	"proxy":           {value: true, warn: "proxies aren't stored in presets"},
+	"cert":            {value: true, warn: "client certificates aren't supported"},
+	"key":             {value: true, warn: "client certificates aren't supported"},
+	"cacert":          {value: true, warn: "custom CA certificates aren't supported"},
+	"capath":          {value: true, warn: "custom CA certificates aren't supported"},
+	"cookie-jar":      {value: true, warn: "cookie jars aren't supported"},
+	"upload-file":     {value: true, warn: "file uploads aren't supported"},
+	"config":          {value: true, warn: "curl config files aren't read"},
+	"netrc":           {warn: "netrc credentials aren't read"},
+	"retry":           {value: true, warn: "retries aren't supported"},
+	"resolve":         {value: true, warn: "custom DNS resolution isn't supported"},
+	"digest":          {warn: "digest auth isn't supported, the credentials were kept as Basic"},
+	"ntlm":            {warn: "NTLM auth isn't supported, the credentials were kept as Basic"},
+	"negotiate":       {warn: "Negotiate auth isn't supported"},
+	"aws-sigv4":       {value: true, warn: "AWS request signing isn't supported"},
+	"unix-socket":     {value: true, warn: "unix sockets aren't supported"},
+}
+
+// curlParser accumulates state while walking curl's arguments
+type curlParser struct {
+	req         *CurlRequest
+	method      string
+	urls        []string
+	data        []string
+	json        bool
+	dataAsQuery bool
+	head        bool
+	cookies     []string
+}
 
-	if !strings.HasPrefix(curlCmd, "curl") {
+// ParseCurl parses a curl command line into a request
+// Anything after the command (| jq, && ...) is ignored
+// Unsupported options are reported in Warnings instead of failing the whole import
+func ParseCurl(curlCmd string) (*CurlRequest, error) {
+	commands, err := SplitShellCommands(curlCmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(commands) == 0 || !isCurlCommand(commands[0]) {
 		return nil, fmt.Errorf("command must start with 'curl'")
 	}
+	return parseCurlArgs(trimPrompt(commands[0]))
+}
 
-	req := &CurlRequest{
-		Method:  "GET",
+// ParseCurlCommands parses every curl command in a script or a pasted list of commands
+// Other commands (export TOKEN=..., cd ...) are skipped
+func ParseCurlCommands(text string) ([]*CurlRequest, error) {
+	commands, err := SplitShellCommands(text)
+	if err != nil {
+		return nil, err
+	}
+	var requests []*CurlRequest
+	for _, command := range commands {
+		if !isCurlCommand(command) {
+			continue
+		}
+		req, err := parseCurlArgs(trimPrompt(command))
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, req)
+	}
+	return requests, nil
+}
+
+// isCurlCommand checks a tokenized command runs curl, allowing a leading "$ " prompt
+func isCurlCommand(args []string) bool {
+	args = trimPrompt(args)
+	return len(args) > 0 && args[0] == "curl"
+}
+
+// trimPrompt drops a "$" prompt copied along with a command from docs
+func trimPrompt(args []string) []string {
+	if len(args) > 0 && args[0] == "$" {
+		return args[1:]
+	}
+	return args
+}
+
+// parseCurlArgs walks curl's arguments; args[0] is "curl" itself
+func parseCurlArgs(args []string) (*CurlRequest, error) {
+	p := &curlParser{req: &CurlRequest{
 		Headers: make(map[string]string),
 		Query:   make(map[string]string),
+	}}
+
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case strings.HasPrefix(arg, "--") && len(arg) > 2:
+			name := arg[2:]
+			option, known := curlOptions[name]
+			if !known {
+				// --no-<flag> turns a boolean flag off
+				if base, negated := strings.CutPrefix(name, "no-"); negated {
+					if option, known := curlOptions[base]; known && !option.value {
+						continue
+					}
+				}
+				p.warn(arg, "unknown option ignored")
+				continue
+			}
+			if option.value {
+				if i+1 >= len(args) {
+					return nil, fmt.Errorf("option %s needs a value", arg)
+				}
+				i++
+				p.apply(arg, option, args[i])
+			} else {
+				p.apply(arg, option, "")
+			}
+
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			// Short flags can be grouped (-sSL) and take their value attached (-XPOST) or as the next argument
+			for j := 1; j < len(arg); j++ {
+				flag := "-" + arg[j:j+1]
+				long, known := curlShortOptions[arg[j]]
+				if !known {
+					p.warn(flag, "unknown option ignored")
+					continue
+				}
+				option := curlOptions[long]
+				if !option.value {
+					p.apply(flag, option, "")
+					continue
+				}
+				value := arg[j+1:]
+				if value == "" {
+					if i+1 >= len(args) {
+						return nil, fmt.Errorf("option %s needs a value", flag)
+					}
+					i++
+					value = args[i]
+				}
+				p.apply(flag, option, value)
+				break
+			}
+
+		default:
+			p.addURL(arg)
+		}
 	}
 
-	// Extract method (-X or --request)
-	methodRegex := regexp.MustCompile(`(?:-X|--request)\s+([A-Z]+)`)
-	if match := methodRegex.FindStringSubmatch(curlCmd); len(match) > 1 {
-		req.Method = match[1]
+	return p.finish(), nil
+}
+
+// apply runs an option's effect, or records why it was dropped
+func (p *curlParser) apply(flag string, option curlOption, arg string) {
+	if option.warn != "" {
+		p.warn(flag, option.warn)
+	}
+	if option.apply != nil {
+		option.apply(p, arg)
 	}
+}
+
+func (p *curlParser) warn(flag, message string) {
+	p.req.Warnings = append(p.req.Warnings, fmt.Sprintf("curl option %s: %s", flag, message))
+}
 
-	// Extract URL (look for http/https URLs with or without quotes)
-	urlRegex := regexp.MustCompile(`(?:'(https?://[^']+)'|"(https?://[^"]+)"|(https?://\S+))`)
-	if match := urlRegex.FindStringSubmatch(curlCmd); len(match) > 1 {
-		if match[1] != "" {
-			req.URL = match[1]
-		} else if match[2] != "" {
-			req.URL = match[2]
-		} else if match[3] != "" {
-			req.URL = match[3]
+func (p *curlParser) addURL(arg string) {
+	p.urls = append(p.urls, arg)
+}
+
+// setHeader sets a header, replacing any earlier one with the same name in any casing
+func (p *curlParser) setHeader(name, value string) {
+	for existing := range p.req.Headers {
+		if strings.EqualFold(existing, name) {
+			delete(p.req.Headers, existing)
 		}
 	}
+	p.req.Headers[name] = value
+}
 
-	// Parse URL for query parameters
-	if req.URL != "" {
-		parsedURL, err := url.Parse(req.URL)
-		if err == nil {
-			// Extract base URL (without query string)
-			req.BaseURL = parsedURL.Scheme + "://" + parsedURL.Host + parsedURL.Path
+// addHeaderLine handles "Name: value", "Name;" (empty value) and "Name:" (curl's way of removing a header)
+func (p *curlParser) addHeaderLine(line string) {
+	if strings.HasPrefix(line, "@") {
+		p.warn("-H", "headers read from files aren't supported")
+		return
+	}
+	name, value, found := strings.Cut(line, ":")
+	if !found {
+		if name, isEmpty := strings.CutSuffix(strings.TrimSpace(line), ";"); isEmpty {
+			p.setHeader(name, "")
+		}
+		return
+	}
+	name = strings.TrimSpace(name)
+	value = strings.TrimSpace(value)
+	if name == "" || value == "" {
+		return
+	}
+	p.setHeader(name, value)
+}
 
-			// Extract query parameters
-			for key, values := range parsedURL.Query() {
-				if len(values) > 0 {
-					req.Query[key] = values[0]
-				}
+// addData handles -d/--data/--data-binary; @file bodies can't be read back later, so they're reported
+func (p *curlParser) addData(arg string) {
+	if strings.HasPrefix(arg, "@") {
+		p.warn("-d", fmt.Sprintf("body from file %s not imported", arg[1:]))
+		return
+	}
+	p.data = append(p.data, arg)
+}
+
+// addURLEncodedData follows curl's --data-urlencode forms: content, =content, name=content, name@file
+func (p *curlParser) addURLEncodedData(arg string) {
+	if eq := strings.IndexByte(arg, '='); eq >= 0 {
+		name, content := arg[:eq], arg[eq+1:]
+		if name == "" {
+			p.data = append(p.data, url.QueryEscape(content))
+		} else {
+			p.data = append(p.data, name+"="+url.QueryEscape(content))
+		}
+		return
+	}
+	if strings.Contains(arg, "@") {
+		p.warn("--data-urlencode", fmt.Sprintf("content from file in '%s' not imported", arg))
+		return
+	}
+	p.data = append(p.data, url.QueryEscape(arg))
+}
+
+// addJSON handles --json, which also sets JSON Content-Type and Accept headers
+func (p *curlParser) addJSON(arg string) {
+	if strings.HasPrefix(arg, "@") {
+		p.warn("--json", fmt.Sprintf("body from file %s not imported", arg[1:]))
+		return
+	}
+	p.json = true
+	p.data = append(p.data, arg)
+}
+
+func (p *curlParser) addForm(arg string) {
+	p.addFormField(arg, true)
+}
+
+// addFormField parses name=value; with files allowed, name=@path and name=<path read from a file
+func (p *curlParser) addFormField(arg string, files bool) {
+	name, value, found := strings.Cut(arg, "=")
+	if !found {
+		p.warn("-F", fmt.Sprintf("malformed form field '%s'", arg))
+		return
+	}
+	isFile := files && (strings.HasPrefix(value, "@") || strings.HasPrefix(value, "<"))
+	if isFile {
+		value = value[1:]
+	} else if files {
+		// Drop ;type=... and friends that curl allows after a value
+		value, _, _ = strings.Cut(value, ";")
+	}
+	p.req.Form = append(p.req.Form, CurlFormField{Name: name, Value: value, IsFile: isFile})
+}
+
+// addBasicAuth turns -u user:password into an Authorization header
+func (p *curlParser) addBasicAuth(arg string) {
+	if !strings.Contains(arg, ":") {
+		p.warn("-u", "no password given, curl would have prompted for one - an empty password was used")
+		arg += ":"
+	}
+	p.setHeader("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(arg)))
+}
+
+// addCookie collects -b name=value cookies; a bare argument is a cookie file
+func (p *curlParser) addCookie(arg string) {
+	if !strings.Contains(arg, "=") {
+		p.warn("-b", fmt.Sprintf("cookie file %s not read", arg))
+		return
+	}
+	p.cookies = append(p.cookies, strings.TrimSuffix(strings.TrimSpace(arg), ";"))
+}
+
+// finish settles the method, URL, query and body once every option has been seen
+func (p *curlParser) finish() *CurlRequest {
+	req := p.req
+
+	if len(p.urls) > 0 {
+		req.URL = p.urls[0]
+		if !strings.Contains(req.URL, "://") {
+			// curl assumes http:// when the scheme is left out
+			req.URL = "http://" + req.URL
+		}
+		if len(p.urls) > 1 {
+			req.Warnings = append(req.Warnings, fmt.Sprintf("only the first URL was imported, %d more ignored", len(p.urls)-1))
+		}
+	}
+
+	if len(p.cookies) > 0 {
+		p.setHeader("Cookie", strings.Join(p.cookies, "; "))
+	}
+	if p.json {
+		for _, name := range []string{"Content-Type", "Accept"} {
+			if !p.hasHeader(name) {
+				req.Headers[name] = "application/json"
 			}
 		}
 	}
 
-	// Extract headers (-H or --header)
-	headerRegex := regexp.MustCompile(`(?:-H|--header)\s+(?:'([^']+)'|"([^"]+)")`)
-	for _, match := range headerRegex.FindAllStringSubmatch(curlCmd, -1) {
-		headerStr := match[1]
-		if headerStr == "" {
-			headerStr = match[2]
+	base, rawQuery, _ := strings.Cut(req.URL, "?")
+	base, _, _ = strings.Cut(base, "#")
+	rawQuery, _, _ = strings.Cut(rawQuery, "#")
+
+	// Data joins with & like curl does, and -G moves it into the query string
+	separator := "&"
+	if p.json {
+		separator = ""
+	}
+	body := strings.Join(p.data, separator)
+	if p.dataAsQuery && body != "" {
+		if rawQuery != "" {
+			rawQuery += "&"
 		}
+		rawQuery += body
+		body = ""
+	}
+	req.Body = body
+	req.BaseURL = base + splitQueryPairs(parseRawQuery(rawQuery), req.Query)
 
-		parts := strings.SplitN(headerStr, ":", 2)
-		if len(parts) == 2 {
-			key := strings.TrimSpace(parts[0])
-			value := strings.TrimSpace(parts[1])
-			req.Headers[key] = value
+	switch {
+	case p.method != "":
+		req.Method = p.method
+	case p.head:
+		req.Method = "HEAD"
+	case req.Body != "" || len(req.Form) > 0:
+		req.Method = "POST"
+	default:
+		req.Method = "GET"
+	}
+
+	return req
+}
+
+func (p *curlParser) hasHeader(name string) bool {
+	for existing := range p.req.Headers {
+		if strings.EqualFold(existing, name) {
+			return true
 		}
 	}
+	return false
+}
+
+// unescapeComponent decodes a query component, keeping it as-is when it isn't valid escaping
+func unescapeComponent(value string) string {
+	if unescaped, err := url.QueryUnescape(value); err == nil {
+		return unescaped
+	}
+	return value
+}
 
-	// Extract body (-d or --data or --data-raw)
-	bodyRegex := regexp.MustCompile(`(?:-d|--data|--data-raw)\s+(?:'([^']*)'|"([^"]*)"|(\S+))`)
-	if match := bodyRegex.FindStringSubmatch(curlCmd); len(match) > 1 {
-		if match[1] != "" {
-			req.Body = match[1]
-		} else if match[2] != "" {
-			req.Body = match[2]
-		} else if match[3] != "" {
-			req.Body = match[3]
+// parseRawQuery decodes a raw query string into ordered key/value pairs
+func parseRawQuery(rawQuery string) [][2]string {
+	var pairs [][2]string
+	for _, pair := range strings.Split(rawQuery, "&") {
+		key, value, _ := strings.Cut(pair, "=")
+		if key != "" {
+			pairs = append(pairs, [2]string{unescapeComponent(key), unescapeComponent(value)})
 		}
 	}
+	return pairs
+}
+
+// splitQueryPairs puts single-valued params in query and returns repeated ones as a "?..." URL suffix
+// query.toml holds one value per key, so repeated keys have to stay in the URL
+func splitQueryPairs(pairs [][2]string, query map[string]string) string {
+	counts := make(map[string]int)
+	for _, pair := range pairs {
+		counts[pair[0]]++
+	}
 
-	return req, nil
-}
\ No newline at end of file
+	var kept []string
+	for _, pair := range pairs {
+		if counts[pair[0]] > 1 {
+			kept = append(kept, url.QueryEscape(pair[0])+"="+url.QueryEscape(pair[1]))
+			continue
+		}
+		query[pair[0]] = pair[1]
+	}
+	if len(kept) == 0 {
+		return ""
+	}
+	return "?" + strings.Join(kept, "&")
+}
@@ -1,35 +1,59 @@
 package core
 
 import (
-	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 )
 
-func TestParseCurlPrint(t *testing.T) {
+func TestParseCurl(t *testing.T) {
 	tests := []struct {
-		name    string
-		curlCmd string
+		name     string
+		curlCmd  string
+		method   string
+		baseURL  string
+		query    map[string]string
+		headers  map[string]string
+		body     string
+		form     []CurlFormField
+		warnings []string // Substrings expected in the warnings, in order
 	}{
 		{
 			name:    "Simple GET",
 			curlCmd: `curl https://api.com`,
+			method:  "GET",
+			baseURL: "https://api.com",
 		},
 		{
 			name:    "POST with body",
 			curlCmd: `curl -X POST https://api.com -d '{"user":"alice"}'`,
+			method:  "POST",
+			baseURL: "https://api.com",
+			body:    `{"user":"alice"}`,
 		},
 		{
 			name:    "With headers",
 			curlCmd: `curl -X POST https://api.com -H 'Authorization: Bearer token' -H 'Content-Type: application/json' -d '{"name":"test"}'`,
+			method:  "POST",
+			baseURL: "https://api.com",
+			headers: map[string]string{"Authorization": "Bearer token", "Content-Type": "application/json"},
+			body:    `{"name":"test"}`,
 		},
 		{
 			name:    "URL with query params",
 			curlCmd: `curl 'https://api.com?foo=bar&baz=qux'`,
+			method:  "GET",
+			baseURL: "https://api.com",
+			query:   map[string]string{"foo": "bar", "baz": "qux"},
 		},
 		{
 			name:    "Complex real-world example",
 			curlCmd: `curl -X POST 'https://api.github.com/repos/owner/repo/issues?state=open' -H 'Authorization: Bearer ghp_token123' -H 'Accept: application/vnd.github+json' -d '{"title":"Bug report","body":"Description here"}'`,
+			method:  "POST",
+			baseURL: "https://api.github.com/repos/owner/repo/issues",
+			query:   map[string]string{"state": "open"},
+			headers: map[string]string{"Authorization": "Bearer ghp_token123", "Accept": "application/vnd.github+json"},
+			body:    `{"title":"Bug report","body":"Description here"}`,
 		},
 		{
 			name: "Instantly.ai POST with multiline body",
@@ -42,48 +66,242 @@ func TestParseCurlPrint(t *testing.T) {
       "user@example.com"
     ]
   }'`,
+			method:  "POST",
+			baseURL: "https://api.instantly.ai/api/v2/accounts/warmup-analytics",
+			headers: map[string]string{"Authorization": "Bearer <YOUR_TOKEN_HERE>", "Content-Type": "application/json"},
+			body:    "{\n    \"emails\": [\n      \"user@example.com\"\n    ]\n  }",
 		},
 		{
 			name:    "Instantly.ai GET with many query params",
 			curlCmd: `curl -i -X GET 'https://api.instantly.ai/api/v2/campaigns/analytics?end_date=2024-01-01&exclude_total_leads_count=true&id=019981cb-fb99-705a-939e-7495cca31a4a&ids=019981cb-fb99-705a-939e-7496f101d2ed&start_date=2024-01-01' -H 'Authorization: Bearer <YOUR_TOKEN_HERE>'`,
+			method:  "GET",
+			baseURL: "https://api.instantly.ai/api/v2/campaigns/analytics",
+			query: map[string]string{
+				"end_date":                  "2024-01-01",
+				"exclude_total_leads_count": "true",
+				"id":                        "019981cb-fb99-705a-939e-7495cca31a4a",
+				"ids":                       "019981cb-fb99-705a-939e-7496f101d2ed",
+				"start_date":                "2024-01-01",
+			},
+			headers: map[string]string{"Authorization": "Bearer <YOUR_TOKEN_HERE>"},
+		},
+		{
+			name:    "Data implies POST and repeated -d joins with &",
+			curlCmd: `curl https://api.com/login -d user=alice -d 'pass=s3cret'`,
+			method:  "POST",
+			baseURL: "https://api.com/login",
+			body:    "user=alice&pass=s3cret",
+		},
+		{
+			name:    "--data-binary, --url and grouped short flags",
+			curlCmd: `curl -sSL --url https://api.com/items --data-binary '{"id":1}'`,
+			method:  "POST",
+			baseURL: "https://api.com/items",
+			body:    `{"id":1}`,
+		},
+		{
+			name:    "--json sets JSON headers",
+			curlCmd: `curl --json '{"a":true}' https://api.com`,
+			method:  "POST",
+			baseURL: "https://api.com",
+			headers: map[string]string{"Content-Type": "application/json", "Accept": "application/json"},
+			body:    `{"a":true}`,
+		},
+		{
+			name:    "Attached values and lowercase method",
+			curlCmd: `curl -XPATCH -H'X-Trace: 1' https://api.com`,
+			method:  "PATCH",
+			baseURL: "https://api.com",
+			headers: map[string]string{"X-Trace": "1"},
+		},
+		{
+			name:    "Basic auth, cookies, user agent",
+			curlCmd: `curl -u alice:pa:ss -b 'session=abc' -b theme=dark -A saul/1.0 https://api.com`,
+			method:  "GET",
+			baseURL: "https://api.com",
+			headers: map[string]string{
+				"Authorization": "Basic YWxpY2U6cGE6c3M=",
+				"Cookie":        "session=abc; theme=dark",
+				"User-Agent":    "saul/1.0",
+			},
+		},
+		{
+			name:    "Header values containing colons and empty headers",
+			curlCmd: `curl https://api.com -H 'X-Time: 12:30:00' -H 'X-Url:https://x.io' -H 'X-Empty;' -H 'Accept:'`,
+			method:  "GET",
+			baseURL: "https://api.com",
+			headers: map[string]string{"X-Time": "12:30:00", "X-Url": "https://x.io", "X-Empty": ""},
+		},
+		{
+			name:    "Escaped quotes inside quotes",
+			curlCmd: `curl https://api.com -d "{\"name\":\"it's \\\"ok\\\"\"}"`,
+			method:  "POST",
+			baseURL: "https://api.com",
+			body:    `{"name":"it's \"ok\""}`,
+		},
+		{
+			name:    "ANSI-C quoting",
+			curlCmd: `curl https://api.com -H $'X-Note: it\'s\tfine' --data-raw $'{"a":"b\u00e9"}'`,
+			method:  "POST",
+			baseURL: "https://api.com",
+			headers: map[string]string{"X-Note": "it's\tfine"},
+			body:    `{"a":"bé"}`,
+		},
+		{
+			name:    "Repeated query keys stay in the URL",
+			curlCmd: `curl 'https://api.com/search?tag=a&tag=b&q=go%20lang'`,
+			method:  "GET",
+			baseURL: "https://api.com/search?tag=a&tag=b",
+			query:   map[string]string{"q": "go lang"},
+		},
+		{
+			name:    "-G moves data into the query",
+			curlCmd: `curl -G https://api.com/search --data-urlencode 'q=hello world' -d limit=5`,
+			method:  "GET",
+			baseURL: "https://api.com/search",
+			query:   map[string]string{"q": "hello world", "limit": "5"},
+		},
+		{
+			name:    "-I is HEAD",
+			curlCmd: `curl -I https://api.com`,
+			method:  "HEAD",
+			baseURL: "https://api.com",
+		},
+		{
+			name:    "Form fields",
+			curlCmd: `curl -F name=alice -F 'avatar=@me.png' -F 'note=hi;type=text/plain' https://api.com/upload`,
+			method:  "POST",
+			baseURL: "https://api.com/upload",
+			form: []CurlFormField{
+				{Name: "name", Value: "alice"},
+				{Name: "avatar", Value: "me.png", IsFile: true},
+				{Name: "note", Value: "hi"},
+			},
+		},
+		{
+			name:     "Unsupported flags become warnings",
+			curlCmd:  `curl -k --compressed --max-time 5 --frobnicate https://api.com`,
+			method:   "GET",
+			baseURL:  "https://api.com",
+			warnings: []string{"-k", "--max-time", "--frobnicate"},
+		},
+		{
+			name:     "Body from file is reported",
+			curlCmd:  `curl -X PUT https://api.com -d @payload.json`,
+			method:   "PUT",
+			baseURL:  "https://api.com",
+			warnings: []string{"payload.json"},
+		},
+		{
+			name:    "Missing scheme defaults to http",
+			curlCmd: `curl localhost:8080/health # liveness check`,
+			method:  "GET",
+			baseURL: "http://localhost:8080/health",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fmt.Println("\n" + strings.Repeat("=", 60))
-			fmt.Printf("Test: %s\n", tt.name)
-			fmt.Println(strings.Repeat("=", 60))
-			fmt.Printf("Input: %s\n\n", tt.curlCmd)
-
 			result, err := ParseCurl(tt.curlCmd)
 			if err != nil {
 				t.Fatalf("Parse error: %v", err)
 			}
 
-			fmt.Printf("Method:   %s\n", result.Method)
-			fmt.Printf("URL:      %s\n", result.URL)
-			fmt.Printf("Base URL: %s\n", result.BaseURL)
-
-			if len(result.Query) > 0 {
-				fmt.Println("\nQuery Parameters:")
-				for key, val := range result.Query {
-					fmt.Printf("  %s = %s\n", key, val)
+			if result.Method != tt.method {
+				t.Errorf("Method = %q, want %q", result.Method, tt.method)
+			}
+			if result.BaseURL != tt.baseURL {
+				t.Errorf("BaseURL = %q, want %q", result.BaseURL, tt.baseURL)
+			}
+			if result.Body != tt.body {
+				t.Errorf("Body = %q, want %q", result.Body, tt.body)
+			}
+			assertStringMap(t, "Query", result.Query, tt.query)
+			assertStringMap(t, "Headers", result.Headers, tt.headers)
+			if len(result.Form) > 0 || len(tt.form) > 0 {
+				if !reflect.DeepEqual(result.Form, tt.form) {
+					t.Errorf("Form = %+v, want %+v", result.Form, tt.form)
 				}
 			}
 
-			if len(result.Headers) > 0 {
-				fmt.Println("\nHeaders:")
-				for key, val := range result.Headers {
-					fmt.Printf("  %s: %s\n", key, val)
+			if len(result.Warnings) != len(tt.warnings) {
+				t.Fatalf("Warnings = %q, want %d warning(s)", result.Warnings, len(tt.warnings))
+			}
+			for i, want := range tt.warnings {
+				if !strings.Contains(result.Warnings[i], want) {
+					t.Errorf("Warnings[%d] = %q, want it to mention %q", i, result.Warnings[i], want)
 				}
 			}
+		})
+	}
+}
 
-			if result.Body != "" {
-				fmt.Printf("\nBody:\n  %s\n", result.Body)
-			}
+func TestParseCurlErrors(t *testing.T) {
+	for _, curlCmd := range []string{
+		`wget https://api.com`,
+		`curl 'https://api.com`,
+		`curl https://api.com -H`,
+	} {
+		if _, err := ParseCurl(curlCmd); err == nil {
+			t.Errorf("ParseCurl(%q) succeeded, want an error", curlCmd)
+		}
+	}
+}
 
-			fmt.Println(strings.Repeat("=", 60))
-		})
+func TestSplitShellWords(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{`a b  c`, []string{"a", "b", "c"}},
+		{`'a b' "c d"`, []string{"a b", "c d"}},
+		{`a\ b`, []string{"a b"}},
+		{"a \\\n b", []string{"a", "b"}},
+		{`"x \"y\" \$z \n"`, []string{`x "y" $z \n`}},
+		{`$'a\nb' $'\x41\101'`, []string{"a\nb", "AA"}},
+		{`pre'fix'"ed"`, []string{"prefixed"}},
+		{`'' x`, []string{"", "x"}},
+		{"a # comment\nb", []string{"a", "b"}},
+		{`a#b`, []string{"a#b"}},
+	}
+
+	for _, tt := range tests {
+		got, err := SplitShellWords(tt.input)
+		if err != nil {
+			t.Errorf("SplitShellWords(%q) error: %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitShellWords(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSplitShellCommands(t *testing.T) {
+	input := "curl a -d 'x;y' | jq .\ncurl b \\\n  -H c && echo done; ls # list ; ignored\n"
+	got, err := SplitShellCommands(input)
+	if err != nil {
+		t.Fatalf("SplitShellCommands error: %v", err)
+	}
+	want := [][]string{
+		{"curl", "a", "-d", "x;y"},
+		{"jq", "."},
+		{"curl", "b", "-H", "c"},
+		{"echo", "done"},
+		{"ls"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitShellCommands = %q, want %q", got, want)
+	}
+}
+
+// assertStringMap compares maps, treating nil and empty as equal
+func assertStringMap(t *testing.T, label string, got, want map[string]string) {
+	t.Helper()
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s = %v, want %v", label, got, want)
 	}
-}
\ No newline at end of file
+}
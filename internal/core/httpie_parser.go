@@ -0,0 +1,195 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// HTTPieRequest is a one-shot request written in HTTPie's item syntax:
+// saul POST https://api.example.com/users name=john age:=30 Authorization:'Bearer x' page==2
+type HTTPieRequest struct {
+	Method  string
+	URL     string // Base URL, repeated query keys stay in it
+	Headers map[string]string
+	Query   map[string]string
+	Body    string // JSON built from the data fields, or the contents of an @file body
+}
+
+// httpieSeparators in the order they're tried at each position, longest first
+var httpieSeparators = []string{":=@", "=@", ":=", "==", "=", ":", "@"}
+
+// HTTPMethods lists the methods accepted for one-shot requests
+var HTTPMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// ParseHTTPieArgs parses a URL followed by HTTPie request items
+// method may be empty: GET, or POST when there's a body, like HTTPie
+func ParseHTTPieArgs(method string, args []string) (*HTTPieRequest, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf(display.ErrMissingURL)
+	}
+
+	req := &HTTPieRequest{
+		Method:  strings.ToUpper(method),
+		Headers: make(map[string]string),
+		Query:   make(map[string]string),
+	}
+
+	base, rawQuery, _ := strings.Cut(expandURLShorthand(args[0]), "?")
+	queryPairs := parseRawQuery(rawQuery)
+	fields := make(map[string]interface{})
+	rawBody := ""
+
+	for _, item := range args[1:] {
+		key, separator, value := splitHTTPieItem(item)
+		switch separator {
+		case "==":
+			queryPairs = append(queryPairs, [2]string{key, value})
+
+		case ":":
+			if key == "" {
+				return nil, fmt.Errorf(display.ErrRequestItemInvalid, item)
+			}
+			// "Header:" with nothing after it unsets a header in HTTPie, there's nothing to unset here
+			if value = strings.TrimSpace(value); value != "" {
+				req.Headers[key] = value
+			}
+
+		case "=", ":=", "=@", ":=@":
+			if key == "" {
+				return nil, fmt.Errorf(display.ErrRequestItemInvalid, item)
+			}
+			fieldValue, err := httpieFieldValue(item, separator, value)
+			if err != nil {
+				return nil, err
+			}
+			if err := setNestedField(fields, key, fieldValue); err != nil {
+				return nil, fmt.Errorf(display.ErrRequestItemInvalid, item)
+			}
+
+		case "@":
+			if key != "" {
+				return nil, fmt.Errorf(display.ErrRequestFileUpload, item)
+			}
+			data, err := os.ReadFile(value)
+			if err != nil {
+				return nil, fmt.Errorf(display.ErrFileLoadFailed, value)
+			}
+			if !json.Valid(data) {
+				return nil, fmt.Errorf(display.ErrRequestBodyNotJSON, value)
+			}
+			rawBody = strings.TrimSpace(string(data))
+
+		default:
+			// "Name;" is HTTPie's way of sending a header with an empty value
+			if name, isEmpty := strings.CutSuffix(item, ";"); isEmpty && name != "" {
+				req.Headers[name] = ""
+				continue
+			}
+			return nil, fmt.Errorf(display.ErrRequestItemInvalid, item)
+		}
+	}
+
+	switch {
+	case rawBody != "" && len(fields) > 0:
+		return nil, fmt.Errorf(display.ErrRequestBodyConflict)
+	case rawBody != "":
+		req.Body = rawBody
+	case len(fields) > 0:
+		data, _ := json.Marshal(fields)
+		req.Body = string(data)
+	}
+
+	if req.Method == "" {
+		req.Method = "GET"
+		if req.Body != "" {
+			req.Method = "POST"
+		}
+	}
+
+	req.URL = base + splitQueryPairs(queryPairs, req.Query)
+	return req, nil
+}
+
+// expandURLShorthand applies HTTPie's URL shortcuts: ":3000/x" is localhost, a missing scheme is http
+func expandURLShorthand(rawURL string) string {
+	if rest, isShorthand := strings.CutPrefix(rawURL, ":"); isShorthand {
+		if rest == "" || strings.HasPrefix(rest, "/") {
+			rawURL = "localhost" + rest
+		} else {
+			rawURL = "localhost:" + rest
+		}
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+	return rawURL
+}
+
+// splitHTTPieItem finds the first unescaped separator in an item
+// A backslash escapes the next character, so 'a\=b=c' is the field "a=b" with value "c"
+func splitHTTPieItem(item string) (key, separator, value string) {
+	var keyBuilder strings.Builder
+	for i := 0; i < len(item); i++ {
+		if item[i] == '\\' && i+1 < len(item) {
+			i++
+			keyBuilder.WriteByte(item[i])
+			continue
+		}
+		for _, candidate := range httpieSeparators {
+			if strings.HasPrefix(item[i:], candidate) {
+				return keyBuilder.String(), candidate, item[i+len(candidate):]
+			}
+		}
+		keyBuilder.WriteByte(item[i])
+	}
+	return item, "", ""
+}
+
+// httpieFieldValue resolves a data field: name=text, name:=json, name=@file and name:=@file.json
+func httpieFieldValue(item, separator, value string) (interface{}, error) {
+	if strings.HasSuffix(separator, "@") {
+		data, err := os.ReadFile(value)
+		if err != nil {
+			return nil, fmt.Errorf(display.ErrFileLoadFailed, value)
+		}
+		value = string(data)
+		if separator == "=@" {
+			return strings.TrimSuffix(value, "\n"), nil
+		}
+	}
+	if separator == "=" || separator == "=@" {
+		return value, nil
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return nil, fmt.Errorf(display.ErrRequestItemJSON, item, err)
+	}
+	return decoded, nil
+}
+
+// setNestedField sets user.name style keys the same way 'saul set body' does
+func setNestedField(fields map[string]interface{}, key string, value interface{}) error {
+	parts := strings.Split(key, ".")
+	current := fields
+	for _, part := range parts[:len(parts)-1] {
+		next, exists := current[part]
+		if !exists {
+			child := make(map[string]interface{})
+			current[part] = child
+			current = child
+			continue
+		}
+		child, isMap := next.(map[string]interface{})
+		if !isMap {
+			return fmt.Errorf("%s is not an object", part)
+		}
+		current = child
+	}
+	current[parts[len(parts)-1]] = value
+	return nil
+}
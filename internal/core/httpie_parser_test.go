@@ -0,0 +1,100 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseHTTPieArgs(t *testing.T) {
+	bodyFile := filepath.Join(t.TempDir(), "body.json")
+	os.WriteFile(bodyFile, []byte("{\"id\": 7}\n"), 0644)
+
+	tests := []struct {
+		name    string
+		method  string
+		args    []string
+		want    HTTPieRequest
+		wantErr bool
+	}{
+		{
+			name:   "Fields, JSON, query and headers",
+			method: "POST",
+			args:   []string{"https://api.com/users?team=core", "name=john", "age:=30", "user.admin:=true", "q==go lang", "Authorization:Bearer t"},
+			want: HTTPieRequest{
+				Method:  "POST",
+				URL:     "https://api.com/users",
+				Headers: map[string]string{"Authorization": "Bearer t"},
+				Query:   map[string]string{"team": "core", "q": "go lang"},
+				Body:    `{"age":30,"name":"john","user":{"admin":true}}`,
+			},
+		},
+		{
+			name: "Method inferred from body and localhost shorthand",
+			args: []string{":3000/items", "title=x"},
+			want: HTTPieRequest{
+				Method:  "POST",
+				URL:     "http://localhost:3000/items",
+				Headers: map[string]string{},
+				Query:   map[string]string{},
+				Body:    `{"title":"x"}`,
+			},
+		},
+		{
+			name: "Escaped separators, empty header and repeated query keys",
+			args: []string{"api.com", `a\=b=c`, "X-Empty;", "tag==a", "tag==b"},
+			want: HTTPieRequest{
+				Method:  "POST",
+				URL:     "http://api.com?tag=a&tag=b",
+				Headers: map[string]string{"X-Empty": ""},
+				Query:   map[string]string{},
+				Body:    `{"a=b":"c"}`,
+			},
+		},
+		{
+			name:   "Raw body from file",
+			method: "put",
+			args:   []string{"https://api.com/x", "@" + bodyFile},
+			want: HTTPieRequest{
+				Method:  "PUT",
+				URL:     "https://api.com/x",
+				Headers: map[string]string{},
+				Query:   map[string]string{},
+				Body:    `{"id": 7}`,
+			},
+		},
+		{name: "Invalid JSON", args: []string{"api.com", "a:=nope"}, wantErr: true},
+		{name: "File upload", args: []string{"api.com", "f@" + bodyFile}, wantErr: true},
+		{name: "Body file and fields", args: []string{"api.com", "a=1", "@" + bodyFile}, wantErr: true},
+		{name: "Unknown item", args: []string{"api.com", "nonsense"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHTTPieArgs(tt.method, tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseHTTPieArgs succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseHTTPieArgs error: %v", err)
+			}
+
+			// Compare bodies as JSON so key order doesn't matter
+			var gotBody, wantBody interface{}
+			json.Unmarshal([]byte(got.Body), &gotBody)
+			json.Unmarshal([]byte(tt.want.Body), &wantBody)
+			if !reflect.DeepEqual(gotBody, wantBody) {
+				t.Errorf("Body = %s, want %s", got.Body, tt.want.Body)
+			}
+			got.Body, tt.want.Body = "", ""
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("got %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
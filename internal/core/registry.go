@@ -0,0 +1,445 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// FlagSpec declares a flag once: its names, whether it takes a value and what it sets
+type FlagSpec struct {
+	Name    string   // Long name without dashes
+	Short   string   // Optional single-letter alias
+	Aliases []string // Other long names that mean the same thing
+	Value   string   // Value placeholder for help, empty for boolean flags
+	List    bool     // Collects the following arguments (-v token name)
+	Usage   string
+	set     func(cmd *Command, value string)
+}
+
+// TakesValue reports whether the flag needs an argument
+func (f *FlagSpec) TakesValue() bool {
+	return f.Value != ""
+}
+
+// Label renders the flag for help output: -o, --output <file>
+func (f *FlagSpec) Label() string {
+	label := "--" + f.Name
+	if f.Short != "" {
+		label = "-" + f.Short + ", " + label
+	}
+	if f.TakesValue() {
+		label += " <" + f.Value + ">"
+	}
+	return label
+}
+
+// CommandSpec declares a command, how it's used and which flags it accepts
+type CommandSpec struct {
+	Name     string
+	Global   bool     // saul <command> rather than saul [preset] <command>
+	Usage    []string // One line per form, without the leading "saul "
+	Summary  string
+	Flags    []string // Long names of the accepted flags
+	Targets  []string // Valid first arguments, used for help and suggestions
+	Examples []string
+}
+
+// Accepts reports whether a flag may be used with this command
+func (c *CommandSpec) Accepts(flag string) bool {
+	if flag == "help" {
+		return true
+	}
+	for _, name := range c.Flags {
+		if name == flag {
+			return true
+		}
+	}
+	return false
+}
+
+var responseFormatFlags = []string{"raw", "body-only", "headers-only", "status-only"}
+
+// Flags is every flag saul understands
+var Flags = []*FlagSpec{
+	{Name: "help", Short: "h", Usage: "Show help for the command", set: func(cmd *Command, _ string) { cmd.Help = true }},
+	{Name: "raw", Usage: "Raw output (JSON, curl), or import a curl command with set", set: func(cmd *Command, _ string) { cmd.RawOutput = true }},
+	{Name: "body-only", Usage: "Show only the response body", set: func(cmd *Command, _ string) { cmd.ResponseFormat = "body-only" }},
+	{Name: "headers-only", Aliases: []string{"header-only"}, Usage: "Show only the response headers", set: func(cmd *Command, _ string) { cmd.ResponseFormat = "headers-only" }},
+	{Name: "status-only", Usage: "Show only the response status", set: func(cmd *Command, _ string) { cmd.ResponseFormat = "status-only" }},
+	{Name: "dry-run", Usage: "Show the request without sending it", set: func(cmd *Command, _ string) { cmd.DryRun = true }},
+	{Name: "call", Usage: "Call the preset right after the change", set: func(cmd *Command, _ string) { cmd.Call = true }},
+	{Name: "variables", Short: "v", Value: "name...", List: true, Usage: "Prompt for these hard variables (all when none are named)", set: func(cmd *Command, value string) {
+		if cmd.VariableFlags == nil {
+			cmd.VariableFlags = []string{}
+		}
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				cmd.VariableFlags = append(cmd.VariableFlags, name)
+			}
+		}
+	}},
+	{Name: "yes", Short: "y", Usage: "Don't ask for confirmation", set: func(cmd *Command, _ string) { cmd.Yes = true }},
+	{Name: "no-history", Usage: "Leave the response history behind", set: func(cmd *Command, _ string) { cmd.NoHistory = true }},
+	{Name: "no-variables", Usage: "Leave variables.toml (stored variable values) behind", set: func(cmd *Command, _ string) { cmd.NoVariables = true }},
+	{Name: "resolved", Usage: "Show the effective values after extends and collections, and where each came from", set: func(cmd *Command, _ string) { cmd.Resolved = true }},
+	{Name: "with-history", Usage: "Keep responses as history (record, import har, export bundle)", set: func(cmd *Command, _ string) { cmd.WithHistory = true }},
+	{Name: "no-redact", Usage: "Keep secrets in the export", set: func(cmd *Command, _ string) { cmd.NoRedact = true }},
+	{Name: "substitute", Usage: "Fill in variables before generating code", set: func(cmd *Command, _ string) { cmd.Substitute = true }},
+	{Name: "clipboard", Usage: "Read the curl command from the clipboard", set: func(cmd *Command, _ string) { cmd.Clipboard = true }},
+	{Name: "from-file", Value: "path", Usage: "Read the curl command from a file", set: func(cmd *Command, value string) { cmd.FromFile = value }},
+	{Name: "port", Value: "port", Usage: "Port to listen on (default 8888)", set: func(cmd *Command, value string) { cmd.Port = value }},
+	{Name: "into", Aliases: []string{"prefix"}, Value: "prefix", Usage: "Prefix for the created preset names (the collection for postman, insomnia and bruno)", set: func(cmd *Command, value string) { cmd.Into = value }},
+	{Name: "upstream", Value: "url", Usage: "Forward everything to this server (reverse proxy mode)", set: func(cmd *Command, value string) { cmd.Upstream = value }},
+	{Name: "filter", Value: "regex", Usage: "Only import requests whose host+path match", set: func(cmd *Command, value string) { cmd.Filter = value }},
+	{Name: "output", Short: "o", Value: "file", Usage: "Write to a file instead of stdout", set: func(cmd *Command, value string) { cmd.Output = value }},
+	{Name: "env", Value: "name", Usage: "Environment file or name to resolve variables from", set: func(cmd *Command, value string) { cmd.Env = value }},
+	{Name: "tag", Value: "name", Usage: "Only import or list what carries this tag", set: func(cmd *Command, value string) { cmd.Tag = value }},
+	{Name: "format", Value: "curl|http", Usage: "Print the preset in another tool's format", set: func(cmd *Command, value string) { cmd.Format = value }},
+	{Name: "lang", Value: "language", Usage: "Generate a go, python, js, httpie or powershell snippet", set: func(cmd *Command, value string) { cmd.Lang = value }},
+	{Name: "history", Usage: "Search stored responses too", set: func(cmd *Command, _ string) { cmd.SearchHistory = true }},
+	{Name: "on-conflict", Value: "rename|overwrite|skip", Usage: "What to do with bundled presets whose name is taken (asks when unset)", set: func(cmd *Command, value string) { cmd.OnConflict = value }},
+	{Name: "json", Usage: "Print JSON, for scripts", set: func(cmd *Command, _ string) { cmd.JSON = true }},
+	{Name: "sort", Value: "field", Usage: "Sort by name, method, url, vars, status or called", set: func(cmd *Command, value string) { cmd.Sort = value }},
+	{Name: "save", Value: "preset", Usage: "Keep the one-shot request as a new preset", set: func(cmd *Command, value string) { cmd.Save = value }},
+}
+
+// Commands is every command saul understands, in help order
+var Commands = []*CommandSpec{
+	{
+		Name: "version", Global: true,
+		Usage:   []string{"version"},
+		Summary: "Show version information",
+	},
+	{
+		Name: "update", Global: true,
+		Usage:   []string{"update"},
+		Summary: "Check for updates",
+	},
+	{
+		Name: "help", Global: true,
+		Usage:   []string{"help [command]"},
+		Summary: "Show help, or everything about one command",
+	},
+	{
+		Name: "ls", Global: true,
+		Usage:   []string{"ls [text...] [--tag name] [--sort field] [--json | --raw]"},
+		Summary: "List presets with their method, URL, variables, last call and tags",
+		Flags:   []string{"tag", "sort", "json", "raw"},
+		Examples: []string{
+			"saul ls github",
+			"saul ls --tag admin --sort called",
+			"saul ls -la    # Flags saul doesn't know go to the system ls",
+		},
+	},
+	{
+		Name: "search", Global: true,
+		Usage:   []string{"search <text...> [--history] [--json | --raw]"},
+		Summary: "Find presets by name, URL, body keys, tags and description, best match first",
+		Flags:   []string{"history", "json", "raw"},
+		Examples: []string{
+			"saul search refund",
+			"saul search order id --history",
+		},
+	},
+	{
+		Name: "use", Global: true,
+		Usage:   []string{"use [preset | -]"},
+		Summary: "Switch to an existing preset, - goes back to the previous one",
+		Examples: []string{
+			"saul use github/issues",
+			"saul use -",
+		},
+	},
+	{
+		Name: "pushd", Global: true,
+		Usage:   []string{"pushd [preset]"},
+		Summary: "Switch presets and remember where you were, popd comes back",
+	},
+	{
+		Name: "popd", Global: true,
+		Usage:   []string{"popd"},
+		Summary: "Go back to the preset before the last pushd",
+	},
+	{
+		Name: "recent", Global: true,
+		Usage:   []string{"recent [--raw]"},
+		Summary: "List the presets this terminal used, with their last call",
+		Flags:   []string{"raw"},
+	},
+	{
+		Name: "where", Global: true,
+		Usage:   []string{"where [--raw]"},
+		Summary: "Show which preset store is in use and why",
+		Flags:   []string{"raw"},
+		Examples: []string{
+			"cd \"$(saul where --raw)\"",
+		},
+	},
+	{
+		Name: "config", Global: true,
+		Usage:   []string{"config get [key]", "config set <key=value...>", "config edit"},
+		Summary: "Show or change your settings in config.toml",
+		Targets: []string{"get", "set", "edit"},
+		Examples: []string{
+			"saul config set timeout=10 output=json",
+			"saul config set headers.User-Agent=saul redact=x-tenant,ssn",
+			"saul config set proxy=",
+			"saul config get timeout",
+		},
+	},
+	{
+		Name: "completion", Global: true,
+		Usage:   []string{"completion bash|zsh|fish|powershell"},
+		Summary: "Print a shell completion script",
+		Targets: []string{"bash", "zsh", "fish", "powershell"},
+		Examples: []string{
+			"source <(saul completion bash)",
+			"saul completion fish > ~/.config/fish/completions/saul.fish",
+			"saul completion powershell | Out-String | Invoke-Expression",
+		},
+	},
+	{
+		Name: "rm", Global: true,
+		Usage:   []string{"rm preset <name...> [--yes]"},
+		Summary: "Delete one or more presets, after asking",
+		Flags:   []string{"yes"},
+	},
+	{
+		Name: "cp", Global: true,
+		Usage:   []string{"cp <preset> <new-name> [--no-history] [--no-variables]"},
+		Summary: "Copy a preset under a new name",
+		Flags:   []string{"no-history", "no-variables"},
+		Examples: []string{
+			"saul cp github-issues gitlab-issues --no-variables",
+		},
+	},
+	{
+		Name: "mv", Global: true,
+		Usage:   []string{"mv <preset> <new-name> [--no-history] [--no-variables]"},
+		Summary: "Rename a preset, sessions follow it",
+		Flags:   []string{"no-history", "no-variables"},
+	},
+	{
+		Name: "request", Global: true,
+		Usage:   []string{"METHOD <url> [items...]", "<url> [items...]"},
+		Summary: "One-shot request in HTTPie syntax, no preset needed",
+		Flags:   append([]string{"save", "dry-run"}, responseFormatFlags...),
+		Examples: []string{
+			"saul POST :3000/users name=john age:=30 Authorization:'Bearer x' page==2",
+			"saul PUT https://api.example.com/items/1 @item.json --save item",
+		},
+	},
+	{
+		Name: "record", Global: true,
+		Usage:   []string{"record [--port 8888] [--into prefix] [--upstream url]"},
+		Summary: "Proxy traffic and save each request as a preset",
+		Flags:   []string{"port", "into", "upstream"},
+	},
+	{
+		Name: "import", Global: true,
+		Usage:   []string{"import curl|har|postman|insomnia|bruno|openapi|http <file>", "import bundle <file.tar.gz> [--on-conflict rename|overwrite|skip]"},
+		Summary: "Create presets from files exported by other tools, or from a saul bundle",
+		Flags:   []string{"into", "filter", "with-history", "env", "tag", "on-conflict"},
+		Targets: []string{"curl", "har", "postman", "insomnia", "bruno", "openapi", "swagger", "http", "bundle"},
+		Examples: []string{
+			"saul import curl requests.sh --into api",
+			"saul import har capture.har --filter api.example.com --with-history",
+			"saul import postman collection.json --env staging.json",
+			"saul import openapi spec.yaml --tag pets --prefix petstore",
+			"saul import bundle team.tar.gz --into shared --on-conflict skip",
+		},
+	},
+	{
+		Name: "export", Global: true,
+		Usage:   []string{"export har [preset...] [-o file]", "export http [preset...] [-o file]", "export [bundle] <preset...> -o file.tar.gz [--with-history]"},
+		Summary: "Export history as HAR, presets as a .http file, or presets as a bundle to share",
+		Flags:   []string{"output", "no-redact", "with-history"},
+		Targets: []string{"har", "http", "bundle"},
+		Examples: []string{
+			"saul export http github -o github.http",
+			"saul export github shop/orders -o team.tar.gz",
+		},
+	},
+	{
+		Name:    "set",
+		Usage:   []string{"[preset] set <target> <key=value...>", "[preset] set url|method|timeout|extends <value>", "[preset] set --raw [--from-file path | --clipboard]"},
+		Summary: "Set values in a target file, or import a curl command with --raw",
+		Flags:   append([]string{"from-file", "clipboard", "call", "variables", "dry-run"}, responseFormatFlags...),
+		Targets: []string{"body", "headers", "header", "query", "request", "variables", "filters", "meta", "url", "method", "timeout", "history", "openapi", "operation", "extends"},
+		Examples: []string{
+			"saul api set url https://api.example.com/users",
+			"saul api set body user.name=john user.tags=[a,b]",
+			"saul api set header Authorization='Bearer {@token}'",
+			"saul api/users set extends api",
+			"pbpaste | saul api set --raw",
+		},
+	},
+	{
+		Name:    "get",
+		Usage:   []string{"[preset] get [target] [key]", "[preset] get --resolved [target] [key]", "[preset] get --format curl|http", "[preset] get --lang <language> [--substitute]"},
+		Summary: "Show configuration, responses or history",
+		Flags:   []string{"raw", "resolved", "format", "lang", "substitute", "body-only", "headers-only", "status-only"},
+		Targets: []string{"body", "headers", "header", "query", "request", "variables", "filters", "meta", "url", "method", "timeout", "response", "history"},
+		Examples: []string{
+			"saul api get body user.name",
+			"saul api get history 1 --body-only",
+			"saul api/users get --resolved headers",
+			"saul api get --lang python --substitute",
+		},
+	},
+	{
+		Name:    "edit",
+		Usage:   []string{"[preset] edit <target> [key]"},
+		Summary: "Edit a field inline, or a whole file in $EDITOR",
+		Flags:   append([]string{"call", "variables", "dry-run"}, responseFormatFlags...),
+		Targets: []string{"body", "headers", "header", "query", "request", "variables", "filters", "meta", "url", "method", "timeout"},
+	},
+	{
+		Name:    "rm",
+		Usage:   []string{"[preset] rm <target> <key...>", "rm preset <name...> [--yes]"},
+		Summary: "Remove keys from body, headers, query or variables",
+		Targets: []string{"body", "headers", "header", "query", "variables"},
+		Examples: []string{
+			"saul api rm body user.email",
+			"saul api rm body 'user.*' tags",
+			"saul api rm header Authorization",
+			"saul rm preset old-api --yes",
+		},
+	},
+	{
+		Name:    "call",
+		Usage:   []string{"[preset] call [-v name...]"},
+		Summary: "Send the preset's request",
+		Flags:   append([]string{"variables", "dry-run"}, responseFormatFlags...),
+		Examples: []string{
+			"saul api call --dry-run",
+			"saul api call -v token --body-only",
+		},
+	},
+	{
+		Name:    "lint",
+		Usage:   []string{"[preset] lint"},
+		Summary: "Check the request and last response against the linked OpenAPI spec",
+	},
+	{
+		Name:    "export",
+		Usage:   []string{"[preset] export har [response numbers...] [-o file]"},
+		Summary: "Export history responses as a HAR 1.2 file",
+		Flags:   []string{"output", "no-redact"},
+		Targets: []string{"har"},
+	},
+}
+
+// LookupFlag finds a flag by long name, alias or short letter
+func LookupFlag(name string) *FlagSpec {
+	for _, flag := range Flags {
+		if flag.Name == name || (flag.Short != "" && flag.Short == name) {
+			return flag
+		}
+		for _, alias := range flag.Aliases {
+			if alias == name {
+				return flag
+			}
+		}
+	}
+	return nil
+}
+
+// LookupCommand finds a global or preset command by name
+func LookupCommand(name string, global bool) *CommandSpec {
+	for _, command := range Commands {
+		if command.Name == name && command.Global == global {
+			return command
+		}
+	}
+	return nil
+}
+
+// IsGlobalCommand reports whether a word starts a global command (saul import ...)
+func IsGlobalCommand(name string) bool {
+	return name != "request" && LookupCommand(name, true) != nil
+}
+
+// CommandNames lists preset command names, or global ones
+func CommandNames(global bool) []string {
+	var names []string
+	for _, command := range Commands {
+		if command.Global == global && command.Name != "request" {
+			names = append(names, command.Name)
+		}
+	}
+	return names
+}
+
+// flagNames lists every spelling of every flag with dashes, for suggestions
+func flagNames() []string {
+	var names []string
+	for _, flag := range Flags {
+		names = append(names, "--"+flag.Name)
+		for _, alias := range flag.Aliases {
+			names = append(names, "--"+alias)
+		}
+	}
+	return names
+}
+
+// DidYouMean returns a " Did you mean 'x'?" hint for the closest candidate, or ""
+func DidYouMean(input string, candidates []string) string {
+	if match := closestMatch(input, candidates); match != "" {
+		return fmt.Sprintf(display.HintDidYouMean, match)
+	}
+	return ""
+}
+
+// closestMatch picks the candidate with the smallest edit distance, if it's close enough to be a typo
+func closestMatch(input string, candidates []string) string {
+	input = strings.ToLower(input)
+	best, bestDistance := "", 0
+	for _, candidate := range candidates {
+		distance := editDistance(input, strings.ToLower(candidate))
+		if best == "" || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	// Allow roughly one typo per three characters
+	if best == "" || bestDistance > max(1, len(input)/3) {
+		return ""
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// CommandFlags returns the flags a command accepts, sorted by name
+func (c *CommandSpec) CommandFlags() []*FlagSpec {
+	var flags []*FlagSpec
+	for _, name := range c.Flags {
+		if flag := LookupFlag(name); flag != nil {
+			flags = append(flags, flag)
+		}
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
@@ -5,24 +5,53 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
+
+	lib "github.com/pelletier/go-toml"
 
 	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/utils"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
 )
 
+// SessionEnv overrides terminal detection, e.g. SAUL_SESSION=deploy to share a session on purpose
+const SessionEnv = "SAUL_SESSION"
+
+// sessionFilePrefix names session files in the store: .session_<id>
+const sessionFilePrefix = ".session_"
+
+// maxRecentPresets is how many presets saul recent remembers per terminal
+const maxRecentPresets = 20
+
+// sessionState is what a session file holds
+// Older versions wrote the bare preset name, which still loads
+type sessionState struct {
+	Preset   string         `toml:"preset"`
+	Previous string         `toml:"previous,omitempty"` // Where 'saul use -' goes back to
+	Stack    []string       `toml:"stack,omitempty"`    // pushd/popd, top first
+	Leader   int            `toml:"leader,omitempty"`   // PID of the shell session owning the file, 0 when it never goes stale
+	Recent   []RecentPreset `toml:"recent,omitempty"`   // Most recently used first
+}
+
+// RecentPreset is a preset this terminal worked with, and how its last call went
+type RecentPreset struct {
+	Name     string    `toml:"name"`
+	UsedAt   time.Time `toml:"used_at"`
+	CalledAt time.Time `toml:"called_at,omitempty"`
+	Status   string    `toml:"status,omitempty"`
+}
+
 // SessionManager encapsulates session state and file operations
 type SessionManager struct {
-	currentPreset string
-	ttyID         string
-	configPath    string
+	state      sessionState
+	ttyID      string
+	leader     int
+	configPath string
 }
 
 // NewSessionManager creates a new session manager with TTY-based session isolation
 func NewSessionManager() (*SessionManager, error) {
-	ttyID, err := getTTYID()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get TTY ID: %v", err)
-	}
+	ttyID, leader := detectSession()
 
 	configPath, err := getConfigPath()
 	if err != nil {
@@ -31,13 +60,14 @@ func NewSessionManager() (*SessionManager, error) {
 
 	sm := &SessionManager{
 		ttyID:      ttyID,
+		leader:     leader,
 		configPath: configPath,
 	}
 
 	// Load existing session
 	if err := sm.LoadSession(); err != nil {
 		// Session load failure is not critical - continue with empty session
-		sm.currentPreset = ""
+		sm.state = sessionState{}
 	}
 
 	return sm, nil
@@ -45,27 +75,110 @@ func NewSessionManager() (*SessionManager, error) {
 
 // GetCurrentPreset returns the current preset for this session
 func (s *SessionManager) GetCurrentPreset() string {
-	return s.currentPreset
+	return s.state.Preset
 }
 
 // SetCurrentPreset sets the current preset and saves the session
+// Switching to another preset remembers the one left behind for 'saul use -'
 func (s *SessionManager) SetCurrentPreset(preset string) error {
-	s.currentPreset = preset
+	if s.state.Preset != "" && s.state.Preset != preset {
+		s.state.Previous = s.state.Preset
+	}
+	s.state.Preset = preset
+	s.touchRecent(preset, func(entry *RecentPreset) { entry.UsedAt = time.Now() })
 	return s.SaveSession()
 }
 
+// GetPreviousPreset returns the preset this session was on before the current one
+func (s *SessionManager) GetPreviousPreset() string {
+	return s.state.Previous
+}
+
+// Stack returns the pushed presets, top first
+func (s *SessionManager) Stack() []string {
+	return s.state.Stack
+}
+
+// PushPreset saves the current preset on the stack and switches to another
+// Without a preset it swaps the current one with the top of the stack, like pushd
+func (s *SessionManager) PushPreset(preset string) error {
+	if preset == "" {
+		if len(s.state.Stack) == 0 {
+			return fmt.Errorf(display.ErrStackEmpty)
+		}
+		preset, s.state.Stack = s.state.Stack[0], s.state.Stack[1:]
+	}
+	if s.state.Preset != "" {
+		s.state.Stack = append([]string{s.state.Preset}, s.state.Stack...)
+	}
+	return s.SetCurrentPreset(preset)
+}
+
+// PopPreset switches back to the preset on top of the stack
+func (s *SessionManager) PopPreset() (string, error) {
+	if len(s.state.Stack) == 0 {
+		return "", fmt.Errorf(display.ErrStackEmpty)
+	}
+	preset := s.state.Stack[0]
+	s.state.Stack = s.state.Stack[1:]
+	return preset, s.SetCurrentPreset(preset)
+}
+
+// RecentPresets lists the presets this terminal used, most recent first
+func (s *SessionManager) RecentPresets() []RecentPreset {
+	return s.state.Recent
+}
+
+// touchRecent moves a preset to the front of the recent list and updates its entry
+func (s *SessionManager) touchRecent(preset string, update func(entry *RecentPreset)) {
+	entry := RecentPreset{Name: preset}
+	recent := []RecentPreset{}
+	for _, existing := range s.state.Recent {
+		if existing.Name == preset {
+			entry = existing
+		} else {
+			recent = append(recent, existing)
+		}
+	}
+	update(&entry)
+	s.state.Recent = append([]RecentPreset{entry}, recent...)
+	if len(s.state.Recent) > maxRecentPresets {
+		s.state.Recent = s.state.Recent[:maxRecentPresets]
+	}
+}
+
+// RecordCall notes when a preset was called in this terminal and the status it got back
+func RecordCall(preset, status string) error {
+	if preset == "" {
+		return nil
+	}
+	sm, err := NewSessionManager()
+	if err != nil {
+		return err
+	}
+	sm.touchRecent(preset, func(entry *RecentPreset) {
+		entry.CalledAt = time.Now()
+		entry.Status = status
+	})
+	return sm.SaveSession()
+}
+
 // LoadSession loads the session from the TTY-specific session file
 func (s *SessionManager) LoadSession() error {
-	sessionFile := s.getSessionFilePath()
-
-	data, err := os.ReadFile(sessionFile)
+	state, err := readSessionFile(s.getSessionFilePath())
 	if err != nil {
 		// Session file doesn't exist - not an error
-		s.currentPreset = ""
+		s.state = sessionState{}
 		return nil
 	}
 
-	s.currentPreset = strings.TrimSpace(string(data))
+	// A terminal number gets reused once its shell is gone, the old session doesn't carry over
+	if state.Leader != 0 && state.Leader != s.leader && !processAlive(state.Leader) {
+		s.state = sessionState{}
+		return nil
+	}
+
+	s.state = state
 	return nil
 }
 
@@ -78,35 +191,207 @@ func (s *SessionManager) SaveSession() error {
 		return fmt.Errorf("failed to create session directory: %v", err)
 	}
 
-	return utils.AtomicWriteFile(sessionFile, []byte(s.currentPreset), config.FilePermissions)
+	// A new terminal is a good moment to sweep up after the closed ones
+	if _, err := os.Stat(sessionFile); os.IsNotExist(err) {
+		cleanStaleSessions(s.configPath)
+	}
+
+	s.state.Leader = s.leader
+	return writeSessionFile(sessionFile, s.state)
 }
 
 // HasCurrentPreset returns true if a current preset is set
 func (s *SessionManager) HasCurrentPreset() bool {
-	return s.currentPreset != ""
+	return s.state.Preset != ""
+}
+
+// SessionID returns the identifier of this terminal's session
+func (s *SessionManager) SessionID() string {
+	return s.ttyID
 }
 
 // getSessionFilePath returns the TTY-specific session file path
 func (s *SessionManager) getSessionFilePath() string {
-	return filepath.Join(s.configPath, fmt.Sprintf(".session_%s", s.ttyID))
+	return filepath.Join(s.configPath, sessionFilePrefix+s.ttyID)
+}
+
+// detectSession identifies the terminal for session isolation, and the shell session owning it
+// Order: $SAUL_SESSION, the controlling terminal, the parent process, then one shared session
+func detectSession() (string, int) {
+	if override := os.Getenv(SessionEnv); override != "" {
+		return sanitizeSessionID(override), 0
+	}
+	if major, minor, leader, ok := controllingTerminal(); ok {
+		return fmt.Sprintf("tty%d-%d", major, minor), leader
+	}
+	// No terminal (scripts, editors, CI): everything run by the same parent shares a session
+	if ppid := os.Getppid(); ppid > 1 {
+		return fmt.Sprintf("ppid%d", ppid), ppid
+	}
+	return "default", 0
+}
+
+// sanitizeSessionID keeps a session name safe to use in a file name
+func sanitizeSessionID(id string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r == '.' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, id)
+}
+
+// readSessionFile loads a session file in either the TOML or the old bare-name format
+func readSessionFile(path string) (sessionState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return sessionState{}, err
+	}
+	var state sessionState
+	if err := lib.Unmarshal(data, &state); err != nil {
+		// A bare preset name isn't valid TOML
+		return sessionState{Preset: strings.TrimSpace(string(data))}, nil
+	}
+	return state, nil
+}
+
+// writeSessionFile saves a session file atomically
+func writeSessionFile(path string, state sessionState) error {
+	data, err := lib.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return utils.AtomicWriteFile(path, data, config.FilePermissions)
 }
 
-// getTTYID gets the current TTY identifier for session isolation
-func getTTYID() (string, error) {
-	tty := os.Getenv("TTY")
-	if tty == "" {
-		// Fallback to simpler TTY detection
-		tty = "default"
-	} else {
-		// Extract just the TTY number/name for filename safety
-		tty = filepath.Base(tty)
-		// Replace any unsafe characters
-		tty = strings.ReplaceAll(tty, "/", "_")
+// sessionFiles lists the session files of the active store
+func sessionFiles(configPath string) ([]string, error) {
+	return filepath.Glob(filepath.Join(configPath, sessionFilePrefix+"*"))
+}
+
+// cleanStaleSessions removes session files whose shell session has ended
+// Files without an owner (SAUL_SESSION, older versions) are left alone
+func cleanStaleSessions(configPath string) {
+	files, err := sessionFiles(configPath)
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		state, err := readSessionFile(file)
+		if err == nil && state.Leader != 0 && !processAlive(state.Leader) {
+			os.Remove(file)
+		}
 	}
-	return tty, nil
 }
 
 // getConfigPath returns the saul configuration directory path using centralized config
 func getConfigPath() (string, error) {
 	return config.GetConfigPath()
-}
\ No newline at end of file
+}
+
+// RenamePresetInSessions points every terminal session that had oldName open at newName
+func RenamePresetInSessions(oldName, newName string) error {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return err
+	}
+	files, err := sessionFiles(configPath)
+	if err != nil {
+		return err
+	}
+	for _, sessionFile := range files {
+		state, err := readSessionFile(sessionFile)
+		if err != nil || !state.renamePreset(oldName, newName) {
+			continue
+		}
+		if err := writeSessionFile(sessionFile, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemovePresetFromSessions forgets a deleted preset, or everything in a deleted collection,
+// in every session that mentions it
+func RemovePresetFromSessions(name string) error {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return err
+	}
+	files, err := sessionFiles(configPath)
+	if err != nil {
+		return err
+	}
+	for _, sessionFile := range files {
+		state, err := readSessionFile(sessionFile)
+		if err != nil || !state.removePreset(name) {
+			continue
+		}
+		if err := writeSessionFile(sessionFile, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removePreset drops a preset, and the presets inside it, from the state, reporting whether any appeared
+func (state *sessionState) removePreset(name string) bool {
+	gone := func(preset string) bool {
+		return preset == name || strings.HasPrefix(preset, name+"/")
+	}
+	removed := false
+	if gone(state.Preset) {
+		state.Preset, removed = "", true
+	}
+	if gone(state.Previous) {
+		state.Previous, removed = "", true
+	}
+	stack := state.Stack[:0]
+	for _, preset := range state.Stack {
+		if gone(preset) {
+			removed = true
+		} else {
+			stack = append(stack, preset)
+		}
+	}
+	state.Stack = stack
+	recent := state.Recent[:0]
+	for _, entry := range state.Recent {
+		if gone(entry.Name) {
+			removed = true
+		} else {
+			recent = append(recent, entry)
+		}
+	}
+	state.Recent = recent
+	return removed
+}
+
+// renamePreset replaces a preset name everywhere in the state, reporting whether it appeared
+// Renaming a collection renames every preset inside it too
+func (state *sessionState) renamePreset(oldName, newName string) bool {
+	renamed := false
+	rename := func(name *string) {
+		if *name == oldName || strings.HasPrefix(*name, oldName+"/") {
+			*name = newName + strings.TrimPrefix(*name, oldName)
+			renamed = true
+		}
+	}
+	rename(&state.Preset)
+	rename(&state.Previous)
+	for i := range state.Stack {
+		rename(&state.Stack[i])
+	}
+	// The new name may already be in the recent list, the first entry stays
+	seen := make(map[string]bool)
+	recent := state.Recent[:0]
+	for _, entry := range state.Recent {
+		rename(&entry.Name)
+		if !seen[entry.Name] {
+			seen[entry.Name] = true
+			recent = append(recent, entry)
+		}
+	}
+	state.Recent = recent
+	return renamed
+}
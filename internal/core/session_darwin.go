@@ -0,0 +1,29 @@
+package core
+
+import (
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// controllingTerminal asks the kernel for the controlling terminal of this process
+// Unlike checking stdin, this still works when every stream is redirected
+func controllingTerminal() (uint32, uint32, int, bool) {
+	info, err := unix.SysctlKinfoProc("kern.proc.pid", os.Getpid())
+	if err != nil || info.Eproc.Tdev == -1 {
+		return 0, 0, 0, false
+	}
+	session, err := unix.Getsid(0)
+	if err != nil {
+		session = 0
+	}
+	device := uint64(uint32(info.Eproc.Tdev))
+	return unix.Major(device), unix.Minor(device), session, true
+}
+
+// processAlive reports whether a process still exists, signal 0 checks without sending anything
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
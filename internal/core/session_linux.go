@@ -0,0 +1,41 @@
+package core
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// controllingTerminal reads the controlling terminal and session id from /proc
+// Unlike checking stdin, this still works when every stream is redirected
+func controllingTerminal() (uint32, uint32, int, bool) {
+	data, err := os.ReadFile("/proc/self/stat")
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	// The command name can hold spaces, the fields start after its closing parenthesis:
+	// state ppid pgrp session tty_nr ...
+	end := strings.LastIndexByte(string(data), ')')
+	if end < 0 {
+		return 0, 0, 0, false
+	}
+	fields := strings.Fields(string(data[end+1:]))
+	if len(fields) < 5 {
+		return 0, 0, 0, false
+	}
+	session, _ := strconv.Atoi(fields[3])
+	ttyNr, err := strconv.ParseUint(fields[4], 10, 32)
+	if err != nil || ttyNr == 0 {
+		return 0, 0, 0, false
+	}
+	major := uint32(ttyNr>>8) & 0xfff
+	minor := uint32(ttyNr&0xff) | uint32(ttyNr>>12)&0xfff00
+	return major, minor, session, true
+}
+
+// processAlive reports whether a process still exists, signal 0 checks without sending anything
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
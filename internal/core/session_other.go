@@ -0,0 +1,13 @@
+//go:build !linux && !darwin
+
+package core
+
+// controllingTerminal isn't detected on this platform, sessions follow the parent process
+func controllingTerminal() (uint32, uint32, int, bool) {
+	return 0, 0, 0, false
+}
+
+// processAlive can't tell here, so session files are never considered stale
+func processAlive(pid int) bool {
+	return true
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
+)
+
+func TestSessionFiles(t *testing.T) {
+	store := t.TempDir()
+	t.Setenv(config.SaulHomeEnv, store)
+	t.Setenv(SessionEnv, "work/api")
+
+	sm, err := NewSessionManager()
+	if err != nil {
+		t.Fatalf("NewSessionManager failed: %v", err)
+	}
+	if sm.SessionID() != "work_api" {
+		t.Errorf("SessionID = %q, want the sanitized SAUL_SESSION", sm.SessionID())
+	}
+	if err := sm.SetCurrentPreset("github/issues"); err != nil {
+		t.Fatalf("SetCurrentPreset failed: %v", err)
+	}
+
+	// Older versions wrote a bare preset name
+	legacy := filepath.Join(store, sessionFilePrefix+"legacy")
+	os.WriteFile(legacy, []byte("old-api\n"), 0644)
+	if state, _ := readSessionFile(legacy); state.Preset != "old-api" {
+		t.Errorf("legacy session = %+v, want old-api", state)
+	}
+
+	// A session whose shell has exited is swept away, one without an owner stays
+	exited := exec.Command("true")
+	if err := exited.Run(); err != nil {
+		t.Skipf("can't start a process to get a dead pid: %v", err)
+	}
+	stale := filepath.Join(store, sessionFilePrefix+"stale")
+	writeSessionFile(stale, sessionState{Preset: "gone", Leader: exited.Process.Pid})
+	cleanStaleSessions(store)
+	if _, err := os.Stat(stale); err == nil && !processAlive(exited.Process.Pid) {
+		t.Errorf("stale session file was left behind")
+	}
+	if _, err := os.Stat(legacy); err != nil {
+		t.Errorf("session file without an owner was removed")
+	}
+
+	if err := RenamePresetInSessions("github/issues", "github/bugs"); err != nil {
+		t.Fatalf("RenamePresetInSessions failed: %v", err)
+	}
+	reloaded, _ := NewSessionManager()
+	if reloaded.GetCurrentPreset() != "github/bugs" {
+		t.Errorf("current preset after rename = %q, want github/bugs", reloaded.GetCurrentPreset())
+	}
+
+	// Renaming the collection carries the presets inside it along, lookalike names stay
+	reloaded.SetCurrentPreset("githubber")
+	reloaded.SetCurrentPreset("github/bugs")
+	RenamePresetInSessions("github", "gh")
+	reloaded, _ = NewSessionManager()
+	if reloaded.GetCurrentPreset() != "gh/bugs" || reloaded.GetPreviousPreset() != "githubber" {
+		t.Errorf("after renaming the collection current = %q, previous = %q", reloaded.GetCurrentPreset(), reloaded.GetPreviousPreset())
+	}
+}
+
+func TestSessionNavigation(t *testing.T) {
+	t.Setenv(config.SaulHomeEnv, t.TempDir())
+	t.Setenv(SessionEnv, "nav")
+
+	sm, _ := NewSessionManager()
+	sm.SetCurrentPreset("api")
+	sm.SetCurrentPreset("auth")
+	if sm.GetPreviousPreset() != "api" {
+		t.Errorf("previous = %q, want api", sm.GetPreviousPreset())
+	}
+
+	if err := sm.PushPreset("billing"); err != nil {
+		t.Fatalf("PushPreset failed: %v", err)
+	}
+	if sm.GetCurrentPreset() != "billing" || len(sm.Stack()) != 1 || sm.Stack()[0] != "auth" {
+		t.Errorf("after pushd: current %q, stack %v", sm.GetCurrentPreset(), sm.Stack())
+	}
+	if popped, err := sm.PopPreset(); err != nil || popped != "auth" || sm.GetCurrentPreset() != "auth" {
+		t.Errorf("popd = %q, %v, current %q", popped, err, sm.GetCurrentPreset())
+	}
+	if _, err := sm.PopPreset(); err == nil {
+		t.Errorf("popd on an empty stack should fail")
+	}
+
+	if err := RecordCall("auth", "201 Created"); err != nil {
+		t.Fatalf("RecordCall failed: %v", err)
+	}
+	// The next command starts from what the call wrote
+	sm, _ = NewSessionManager()
+	sm.PushPreset("api")
+	RenamePresetInSessions("auth", "login")
+
+	reloaded, _ := NewSessionManager()
+	recent := reloaded.RecentPresets()
+	if len(recent) != 3 || recent[0].Name != "api" || recent[1].Name != "login" {
+		t.Fatalf("recent = %+v, want api, login, billing", recent)
+	}
+	if recent[1].Status != "201 Created" || recent[1].CalledAt.IsZero() {
+		t.Errorf("recorded call lost: %+v", recent[1])
+	}
+	if !recent[0].CalledAt.IsZero() {
+		t.Errorf("a preset never called has a call time: %+v", recent[0])
+	}
+	if stack := reloaded.Stack(); len(stack) != 1 || stack[0] != "login" {
+		t.Errorf("stack after rename = %v, want [login]", stack)
+	}
+}
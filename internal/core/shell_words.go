@@ -0,0 +1,226 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SplitShellWords splits a command line the way a POSIX shell would before running it
+// Handles '...', "...", $'...' (ANSI-C escapes), backslash escapes, line continuations and # comments
+// Nothing is expanded: $VAR and $(cmd) are kept as literal text
+func SplitShellWords(input string) ([]string, error) {
+	commands, err := splitShell(input, false)
+	if err != nil || len(commands) == 0 {
+		return nil, err
+	}
+	return commands[0], nil
+}
+
+// SplitShellCommands splits a script into commands at unquoted newlines, ;, |, && and ||
+// Each command is split into words like SplitShellWords, empty commands are dropped
+func SplitShellCommands(input string) ([][]string, error) {
+	return splitShell(input, true)
+}
+
+// splitShell does the tokenizing for both; with separators off, newlines are plain whitespace
+func splitShell(input string, separators bool) ([][]string, error) {
+	var commands [][]string
+	var words []string
+	var word strings.Builder
+	inWord := false
+
+	finish := func() {
+		if inWord {
+			words = append(words, word.String())
+			word.Reset()
+			inWord = false
+		}
+	}
+	endCommand := func() {
+		finish()
+		if len(words) > 0 {
+			commands = append(commands, words)
+			words = nil
+		}
+	}
+
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case separators && (c == '\n' || c == ';' || c == '|'):
+			endCommand()
+			if c == '|' && i+1 < len(input) && input[i+1] == '|' {
+				i++
+			}
+
+		case separators && c == '&' && i+1 < len(input) && input[i+1] == '&':
+			endCommand()
+			i++
+
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			finish()
+
+		case c == '#' && !inWord:
+			// Comment runs to the end of the line
+			for i+1 < len(input) && input[i+1] != '\n' {
+				i++
+			}
+
+		case c == '\\':
+			if i+1 >= len(input) {
+				continue
+			}
+			i++
+			if input[i] == '\n' {
+				continue // line continuation
+			}
+			if input[i] == '\r' && i+1 < len(input) && input[i+1] == '\n' {
+				i++
+				continue
+			}
+			word.WriteByte(input[i])
+			inWord = true
+
+		case c == '\'':
+			end := strings.IndexByte(input[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			word.WriteString(input[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+
+		case c == '"':
+			next, err := readDoubleQuoted(input, i+1, &word)
+			if err != nil {
+				return nil, err
+			}
+			i = next
+			inWord = true
+
+		case c == '$' && i+1 < len(input) && input[i+1] == '\'':
+			next, err := readANSIQuoted(input, i+2, &word)
+			if err != nil {
+				return nil, err
+			}
+			i = next
+			inWord = true
+
+		case c == '$' && i+1 < len(input) && input[i+1] == '"':
+			// $"..." is a locale-translated string, the same as "..." for us
+			next, err := readDoubleQuoted(input, i+2, &word)
+			if err != nil {
+				return nil, err
+			}
+			i = next
+			inWord = true
+
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	endCommand()
+
+	return commands, nil
+}
+
+// readDoubleQuoted reads a "..." string starting after the opening quote and returns the closing quote index
+// Inside double quotes a backslash only escapes $ ` " \ and newline
+func readDoubleQuoted(input string, start int, word *strings.Builder) (int, error) {
+	for i := start; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case c == '"':
+			return i, nil
+		case c == '\\' && i+1 < len(input):
+			switch input[i+1] {
+			case '$', '`', '"', '\\':
+				word.WriteByte(input[i+1])
+				i++
+			case '\n':
+				i++
+			default:
+				word.WriteByte(c)
+			}
+		default:
+			word.WriteByte(c)
+		}
+	}
+	return 0, fmt.Errorf("unterminated double quote")
+}
+
+// readANSIQuoted reads a $'...' string starting after the opening quote and returns the closing quote index
+func readANSIQuoted(input string, start int, word *strings.Builder) (int, error) {
+	for i := start; i < len(input); i++ {
+		c := input[i]
+		if c == '\'' {
+			return i, nil
+		}
+		if c != '\\' || i+1 >= len(input) {
+			word.WriteByte(c)
+			continue
+		}
+
+		i++
+		switch esc := input[i]; esc {
+		case 'n':
+			word.WriteByte('\n')
+		case 't':
+			word.WriteByte('\t')
+		case 'r':
+			word.WriteByte('\r')
+		case 'a':
+			word.WriteByte('\a')
+		case 'b':
+			word.WriteByte('\b')
+		case 'f':
+			word.WriteByte('\f')
+		case 'v':
+			word.WriteByte('\v')
+		case 'e', 'E':
+			word.WriteByte(0x1b)
+		case 'x', 'u', 'U':
+			// \xHH, \uHHHH and \UHHHHHHHH
+			digits := map[byte]int{'x': 2, 'u': 4, 'U': 8}[esc]
+			end := i + 1
+			for end < len(input) && end-i-1 < digits && isHexDigit(input[end]) {
+				end++
+			}
+			if end == i+1 {
+				word.WriteByte('\\')
+				word.WriteByte(esc)
+				continue
+			}
+			code, _ := strconv.ParseUint(input[i+1:end], 16, 32)
+			if esc == 'x' {
+				word.WriteByte(byte(code))
+			} else {
+				var buf [utf8.UTFMax]byte
+				word.Write(buf[:utf8.EncodeRune(buf[:], rune(code))])
+			}
+			i = end - 1
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			// Octal \nnn
+			end := i
+			for end < len(input) && end-i < 3 && input[end] >= '0' && input[end] <= '7' {
+				end++
+			}
+			code, _ := strconv.ParseUint(input[i:end], 8, 8)
+			word.WriteByte(byte(code))
+			i = end - 1
+		case '\\', '\'', '"', '?':
+			word.WriteByte(esc)
+		default:
+			word.WriteByte('\\')
+			word.WriteByte(esc)
+		}
+	}
+	return 0, fmt.Errorf("unterminated $'...' quote")
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
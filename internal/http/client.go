@@ -24,7 +24,14 @@ type HTTPRequestConfig struct {
 }
 
 // LoadPresetFile loads a single TOML file as a handler, returns empty handler if file doesn't exist
+// What the preset extends and its collections share sits underneath its own values,
+// with header names matched case-insensitively across those layers
 func LoadPresetFile(preset, filename string) *workspace.TomlHandler {
+	if resolved, err := workspace.LoadResolvedPresetFile(preset, filename); err == nil {
+		return resolved
+	}
+
+	// Broken inheritance still leaves the preset's own values to work with
 	presetPath, err := workspace.GetPresetPath(preset)
 	if err != nil {
 		// Return empty handler if preset path fails
@@ -35,7 +42,7 @@ func LoadPresetFile(preset, filename string) *workspace.TomlHandler {
 	handler, err := workspace.NewTomlHandler(filePath)
 	if err != nil {
 		// Return empty handler if file doesn't exist or can't be loaded
-		return createEmptyHandler()
+		handler = createEmptyHandler()
 	}
 	return handler
 }
@@ -80,7 +87,7 @@ func BuildHTTPRequestFromHandlers(requestHandler, headersHandler, bodyHandler, q
 		}
 	}
 	if config.Timeout == 0 {
-		config.Timeout = 30 // Default timeout
+		config.Timeout = userSettings().TimeoutSeconds()
 	}
 
 	// Extract headers ONLY from headers handler
@@ -122,6 +129,10 @@ func BuildHTTPRequestFromHandlers(requestHandler, headersHandler, bodyHandler, q
 func ExecuteHTTPRequest(config *HTTPRequestConfig) (*resty.Response, error) {
 	client := resty.New()
 	client.SetTimeout(time.Duration(config.Timeout) * time.Second)
+	// Without a proxy in config.toml, resty keeps following HTTP_PROXY and friends
+	if proxy := userSettings().Proxy; proxy != "" {
+		client.SetProxy(proxy)
+	}
 
 	request := client.R()
 
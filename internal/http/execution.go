@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-resty/resty/v2"
 	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/variables"
@@ -30,9 +31,14 @@ func ExecuteCallCommand(cmd core.Command) error {
 		return fmt.Errorf(display.ErrPresetNotFound, cmd.Preset)
 	}
 
-	// Check for flags
+	// A broken extends chain should stop the call, not quietly drop the inherited values
+	if _, err := workspace.LoadInheritedFile(cmd.Preset, "request"); err != nil {
+		return err
+	}
+
+	// Check for flags, --raw wins over the output mode in config.toml
 	persist := false
-	rawMode := cmd.RawOutput
+	rawMode := cmd.RawOutput || userSettings().OutputMode() == config.OutputRaw
 
 	// Prompt for variables and get substitution map
 	var substitutions map[string]string
@@ -78,6 +84,19 @@ func ExecuteCallCommand(cmd core.Command) error {
 		return fmt.Errorf(display.ErrRequestBuildFailed)
 	}
 
+	// Validate against the linked OpenAPI operation, violations never block the call
+	operation, err := workspace.LoadPresetOperation(cmd.Preset)
+	if err != nil && !rawMode {
+		display.Warning(fmt.Sprintf(display.WarnSpecLoadFailed, err))
+	}
+	var violations []string
+	if operation != nil {
+		violations = operation.ValidateRequest(request.Headers, request.Query, request.Body)
+		if !rawMode {
+			reportViolations("Request", operation, violations)
+		}
+	}
+
 	// Handle dry-run mode
 	if cmd.DryRun {
 		return displayDryRunRequest(request)
@@ -89,8 +108,19 @@ func ExecuteCallCommand(cmd core.Command) error {
 		return fmt.Errorf(display.ErrHTTPRequestFailed)
 	}
 
+	// Failing to note the call in saul recent never fails the call
+	core.RecordCall(cmd.Preset, response.Status())
+
+	if operation != nil {
+		responseViolations := operation.ValidateResponse(response.StatusCode(), response.Body())
+		if !rawMode {
+			reportViolations("Response", operation, responseViolations)
+		}
+		violations = append(violations, responseViolations...)
+	}
+
 	// Check if history is enabled and store response
-	err = storeResponseHistory(cmd.Preset, request, response)
+	err = storeResponseHistory(cmd.Preset, request, response, violations)
 	if err != nil {
 		// Don't fail the whole request if history storage fails
 		display.Warning(display.WarnHistoryFailed)
@@ -103,17 +133,17 @@ func ExecuteCallCommand(cmd core.Command) error {
 }
 
 // storeResponseHistory stores the HTTP response in history if enabled
-func storeResponseHistory(preset string, request *HTTPRequestConfig, response *resty.Response) error {
+func storeResponseHistory(preset string, request *HTTPRequestConfig, response *resty.Response, violations []string) error {
 	// Load request.toml to check for history configuration
 	requestHandler, err := workspace.LoadPresetFile(preset, "request")
 	if err != nil {
 		return nil // If we can't load request config, skip history
 	}
 
-	// Get history count from request.toml
+	// Get history count from request.toml - config.toml only seeds it for new presets
 	historyCountValue := requestHandler.Get("history_count")
 	if historyCountValue == nil {
-		return nil // No history configured
+		return nil // History never enabled for this preset
 	}
 
 	// Convert to int
@@ -160,17 +190,25 @@ func storeResponseHistory(preset string, request *HTTPRequestConfig, response *r
 
 	// Store the response
 	responseData := workspace.HistoryResponse{
-		Method:   request.Method,
-		URL:      request.URL,
-		Status:   response.Status(),
-		Duration: duration,
-		Headers:  headers,
-		Body:     body,
+		Method:     request.Method,
+		URL:        request.URL,
+		Status:     response.Status(),
+		Duration:   duration,
+		Headers:    headers,
+		Body:       body,
+		Request:    workspace.NewHistoryRequest(request.Headers, request.Query, string(request.Body)),
+		Violations: violations,
 	}
 
 	return workspace.StoreResponse(preset, responseData, historyCount)
 }
 
+// userSettings returns the config.toml settings
+// BuildHTTPRequestFromHandlers and ExecuteHTTPRequest name their request config 'config', shadowing the package
+func userSettings() *config.Config {
+	return config.Current()
+}
+
 // displayDryRunRequest shows request details without executing
 func displayDryRunRequest(request *HTTPRequestConfig) error {
 	fmt.Printf("%s %s\n", request.Method, request.URL)
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// ExecuteLintCommand validates a preset against its linked OpenAPI operation without sending it
+// Unresolved variables are treated as wildcards, the latest history response is checked too
+func ExecuteLintCommand(cmd core.Command) error {
+	if cmd.Preset == "" {
+		return fmt.Errorf(display.ErrPresetNameRequired)
+	}
+	if !workspace.PresetExists(cmd.Preset) {
+		return fmt.Errorf(display.ErrPresetNotFound, cmd.Preset)
+	}
+
+	operation, err := workspace.LoadPresetOperation(cmd.Preset)
+	if err != nil {
+		return err
+	}
+	if operation == nil {
+		return fmt.Errorf(display.ErrLintNoSpec, cmd.Preset, cmd.Preset)
+	}
+
+	request, err := BuildHTTPRequestFromHandlers(
+		LoadPresetFile(cmd.Preset, "request"),
+		LoadPresetFile(cmd.Preset, "headers"),
+		LoadPresetFile(cmd.Preset, "body"),
+		LoadPresetFile(cmd.Preset, "query"),
+	)
+	if err != nil {
+		return fmt.Errorf(display.ErrRequestBuildFailed)
+	}
+
+	violations := operation.ValidateRequest(request.Headers, request.Query, request.Body)
+	reportViolations("Request", operation, violations)
+	total := len(violations)
+
+	responses, err := workspace.ListHistoryResponses(cmd.Preset)
+	if err == nil && len(responses) > 0 {
+		latest := responses[len(responses)-1]
+		if status, ok := historyStatusCode(latest.Status); ok {
+			body, _ := latest.Body.(string)
+			violations = operation.ValidateResponse(status, []byte(body))
+			reportViolations("Last response", operation, violations)
+			total += len(violations)
+		}
+	}
+
+	if total > 0 {
+		return fmt.Errorf(display.ErrLintViolations, total)
+	}
+	// Silent success - Unix philosophy
+	return nil
+}
+
+// reportViolations prints spec violations under a short heading
+func reportViolations(subject string, operation *workspace.OpenAPIOperation, violations []string) {
+	if len(violations) == 0 {
+		return
+	}
+	display.Warning(fmt.Sprintf(display.WarnSpecViolations, subject, operation.ID))
+	for _, violation := range violations {
+		display.Warning("  ! " + violation)
+	}
+}
+
+// historyStatusCode extracts 200 from a stored "200 OK" status
+func historyStatusCode(status string) (int, bool) {
+	code, err := strconv.Atoi(strings.Fields(status + " ")[0])
+	return code, err == nil
+}
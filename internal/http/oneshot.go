@@ -0,0 +1,96 @@
+package http
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// ExecuteOneShotCommand sends an HTTPie-style request without touching any preset
+// With --save the request is written as a regular preset first and called from there
+func ExecuteOneShotCommand(cmd core.Command) error {
+	parsed, err := core.ParseHTTPieArgs(cmd.Target, cmd.Targets)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Save != "" {
+		// Writing into an existing preset would merge its headers, query and body into the call
+		if workspace.PresetExists(cmd.Save) {
+			return fmt.Errorf(display.ErrPresetAlreadyExists, cmd.Save)
+		}
+		err := workspace.WritePresetRequest(cmd.Save, workspace.PresetRequest{
+			Method:  parsed.Method,
+			URL:     parsed.URL,
+			Headers: parsed.Headers,
+			Query:   parsed.Query,
+			Body:    parsed.Body,
+		})
+		if err != nil {
+			return err
+		}
+		cmd.Preset = cmd.Save
+		return ExecuteCallCommand(cmd)
+	}
+
+	// Same handlers a preset call builds, kept in memory
+	requestHandler := createEmptyHandler()
+	headersHandler := createEmptyHandler()
+	queryHandler := createEmptyHandler()
+	bodyHandler := createEmptyHandler()
+	if requestHandler == nil || headersHandler == nil || queryHandler == nil || bodyHandler == nil {
+		return fmt.Errorf(display.ErrRequestBuildFailed)
+	}
+
+	requestHandler.Set("method", parsed.Method)
+	requestHandler.Set("url", parsed.URL)
+	// Headers from config.toml go first so the command line can replace them
+	for key, value := range config.Current().Headers {
+		if !hasHeaderFold(parsed.Headers, key) {
+			headersHandler.Set(key, value)
+		}
+	}
+	for key, value := range parsed.Headers {
+		headersHandler.Set(key, value)
+	}
+	for key, value := range parsed.Query {
+		queryHandler.Set(key, value)
+	}
+	if parsed.Body != "" {
+		bodyHandler, err = workspace.NewTomlHandlerFromJSON([]byte(parsed.Body))
+		if err != nil {
+			return fmt.Errorf(display.ErrRequestBuildFailed)
+		}
+	}
+
+	request, err := BuildHTTPRequestFromHandlers(requestHandler, headersHandler, bodyHandler, queryHandler)
+	if err != nil {
+		return err
+	}
+
+	if cmd.DryRun {
+		return displayDryRunRequest(request)
+	}
+
+	response, err := ExecuteHTTPRequest(request)
+	if err != nil {
+		return fmt.Errorf(display.ErrHTTPRequestFailed)
+	}
+
+	DisplayResponse(response, cmd.RawOutput || config.Current().OutputMode() == config.OutputRaw, "", cmd.ResponseFormat)
+	return nil
+}
+
+// hasHeaderFold reports whether a header is set under any capitalization
+func hasHeaderFold(headers map[string]string, name string) bool {
+	for key := range headers {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,313 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	nethttp "net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+const (
+	defaultRecordPort   = "8888"
+	defaultRecordPrefix = "recorded"
+)
+
+// hopByHopHeaders are connection-scoped headers that must not be forwarded or stored
+var hopByHopHeaders = []string{
+	"Connection", "Proxy-Connection", "Keep-Alive", "Proxy-Authenticate",
+	"Proxy-Authorization", "Te", "Trailer", "Transfer-Encoding", "Upgrade",
+}
+
+// recorder is a forward/reverse proxy that turns every distinct request into a preset
+type recorder struct {
+	prefix   string
+	upstream *url.URL
+	client   *nethttp.Client
+
+	mu       sync.Mutex
+	seen     map[string]string // "METHOD host/path" -> preset name
+	tunneled map[string]bool   // hosts already warned about CONNECT tunneling
+	recorded int
+}
+
+// ExecuteRecordCommand runs the recording proxy until interrupted
+func ExecuteRecordCommand(cmd core.Command) error {
+	port := cmd.Port
+	if port == "" {
+		port = defaultRecordPort
+	}
+	prefix := cmd.Into
+	if prefix == "" {
+		prefix = defaultRecordPrefix
+	}
+
+	var upstream *url.URL
+	if cmd.Upstream != "" {
+		parsed, err := url.Parse(cmd.Upstream)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf(display.ErrRecordUpstreamInvalid, cmd.Upstream)
+		}
+		upstream = parsed
+	}
+	rec := newRecorder(prefix, upstream)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:"+port)
+	if err != nil {
+		return fmt.Errorf(display.ErrRecordListenFailed, port, err)
+	}
+
+	server := &nethttp.Server{Handler: rec}
+
+	if rec.upstream != nil {
+		display.Info(fmt.Sprintf(display.InfoRecordReverse, port, rec.upstream.String()))
+	} else {
+		display.Info(fmt.Sprintf(display.InfoRecordForward, port))
+	}
+	display.Tip(fmt.Sprintf("New presets go to '%s-*' - press Ctrl+C to stop", prefix))
+
+	// Stop cleanly on Ctrl+C so the summary gets printed
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-stop
+		server.Close()
+	}()
+
+	err = server.Serve(listener)
+	if err != nil && err != nethttp.ErrServerClosed {
+		return err
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	display.Info(fmt.Sprintf(display.InfoRecordSummary, rec.recorded))
+	return nil
+}
+
+// newRecorder sets up a recorder, forward proxy when upstream is nil
+func newRecorder(prefix string, upstream *url.URL) *recorder {
+	return &recorder{
+		prefix:   prefix,
+		upstream: upstream,
+		seen:     make(map[string]string),
+		tunneled: make(map[string]bool),
+		client: &nethttp.Client{
+			Timeout: time.Duration(config.Current().TimeoutSeconds()) * time.Second,
+			// Never follow redirects - the client behind the proxy decides that
+			CheckRedirect: func(*nethttp.Request, []*nethttp.Request) error {
+				return nethttp.ErrUseLastResponse
+			},
+			// No proxy from the environment, otherwise HTTP_PROXY could point back at us
+			Transport: &nethttp.Transport{Proxy: nil},
+		},
+	}
+}
+
+// ServeHTTP forwards a single request upstream and records it
+func (rec *recorder) ServeHTTP(w nethttp.ResponseWriter, r *nethttp.Request) {
+	if r.Method == nethttp.MethodConnect {
+		rec.tunnel(w, r)
+		return
+	}
+
+	target, err := rec.resolveTarget(r)
+	if err != nil {
+		nethttp.Error(w, err.Error(), nethttp.StatusBadGateway)
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		nethttp.Error(w, err.Error(), nethttp.StatusBadRequest)
+		return
+	}
+
+	outgoing, err := nethttp.NewRequestWithContext(r.Context(), r.Method, target.String(), bytes.NewReader(requestBody))
+	if err != nil {
+		nethttp.Error(w, err.Error(), nethttp.StatusBadGateway)
+		return
+	}
+	outgoing.Header = r.Header.Clone()
+	removeHopByHop(outgoing.Header)
+	// Let the transport negotiate compression so recorded bodies are plain text
+	outgoing.Header.Del("Accept-Encoding")
+
+	start := time.Now()
+	response, err := rec.client.Do(outgoing)
+	if err != nil {
+		nethttp.Error(w, err.Error(), nethttp.StatusBadGateway)
+		return
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	duration := time.Since(start)
+	if err != nil {
+		nethttp.Error(w, err.Error(), nethttp.StatusBadGateway)
+		return
+	}
+
+	// Relay the response back to the client
+	for key, values := range response.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	removeHopByHop(w.Header())
+	w.Header().Del("Content-Length")
+	w.WriteHeader(response.StatusCode)
+	w.Write(responseBody)
+
+	rec.record(r, target, requestBody, response, responseBody, duration)
+}
+
+// resolveTarget works out the upstream URL: absolute URIs are forward-proxied,
+// relative ones are sent to the --upstream base URL
+func (rec *recorder) resolveTarget(r *nethttp.Request) (*url.URL, error) {
+	if r.URL.IsAbs() {
+		return r.URL, nil
+	}
+	if rec.upstream == nil {
+		return nil, fmt.Errorf(display.ErrRecordNoUpstream, r.URL.Path)
+	}
+
+	target := *rec.upstream
+	target.Path = strings.TrimSuffix(rec.upstream.Path, "/") + r.URL.Path
+	target.RawPath = ""
+	target.RawQuery = r.URL.RawQuery
+	return &target, nil
+}
+
+// record writes the request as a new preset with its response as the first history entry
+func (rec *recorder) record(r *nethttp.Request, target *url.URL, requestBody []byte, response *nethttp.Response, responseBody []byte, duration time.Duration) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	key := r.Method + " " + target.Host + target.Path
+	if _, exists := rec.seen[key]; exists {
+		return
+	}
+
+	preset := workspace.UniquePresetName(workspace.PresetNameFor(rec.prefix, r.Method, target.Path))
+	rec.seen[key] = preset
+
+	request := workspace.PresetRequest{
+		Method:  r.Method,
+		URL:     target.Scheme + "://" + target.Host + target.Path,
+		Headers: make(map[string]string),
+		Query:   make(map[string]string),
+	}
+	for name, values := range r.Header {
+		if len(values) > 0 && !workspace.IsTransportHeader(name) {
+			request.Headers[name] = values[0]
+		}
+	}
+	for name, values := range target.Query() {
+		if len(values) > 0 {
+			request.Query[name] = values[0]
+		}
+	}
+	if len(requestBody) > 0 {
+		// body.toml is a TOML table, so array and plain-text bodies can't be kept
+		if workspace.IsJSONObject(requestBody) {
+			request.Body = string(requestBody)
+		} else {
+			display.Warning(fmt.Sprintf(display.WarnRecordBodySkipped, preset))
+		}
+	}
+
+	if err := workspace.WritePresetRequest(preset, request); err != nil {
+		display.Warning(fmt.Sprintf(display.WarnRecordFailed, preset, err))
+		return
+	}
+
+	// Enable history so the recorded response (and later calls) are kept
+	if err := workspace.EnableHistory(preset, workspace.DefaultImportHistoryCount); err != nil {
+		display.Warning(display.WarnHistoryFailed)
+	}
+
+	headers := make(map[string]string)
+	for name, values := range response.Header {
+		if len(values) > 0 {
+			headers[name] = values[0]
+		}
+	}
+	var body interface{}
+	if len(responseBody) > 0 {
+		body = string(responseBody)
+	}
+	err := workspace.StoreResponse(preset, workspace.HistoryResponse{
+		Method:   r.Method,
+		URL:      request.URL,
+		Status:   response.Status,
+		Duration: fmt.Sprintf("%.3fs", duration.Seconds()),
+		Headers:  headers,
+		Body:     body,
+		Request:  workspace.NewHistoryRequest(request.Headers, request.Query, string(requestBody)),
+	}, workspace.DefaultImportHistoryCount)
+	if err != nil {
+		display.Warning(display.WarnHistoryFailed)
+	}
+
+	rec.recorded++
+	display.Plain(fmt.Sprintf("  + %-30s %-6s %s %d", preset, r.Method, target.Path, response.StatusCode))
+}
+
+// tunnel blindly relays CONNECT traffic - HTTPS through a forward proxy can't be recorded
+func (rec *recorder) tunnel(w nethttp.ResponseWriter, r *nethttp.Request) {
+	rec.mu.Lock()
+	if !rec.tunneled[r.Host] {
+		rec.tunneled[r.Host] = true
+		display.Warning(fmt.Sprintf(display.WarnRecordTunnel, r.Host))
+	}
+	rec.mu.Unlock()
+
+	destination, err := net.DialTimeout("tcp", r.Host, 10*time.Second)
+	if err != nil {
+		nethttp.Error(w, err.Error(), nethttp.StatusBadGateway)
+		return
+	}
+
+	hijacker, ok := w.(nethttp.Hijacker)
+	if !ok {
+		destination.Close()
+		nethttp.Error(w, "tunneling not supported", nethttp.StatusInternalServerError)
+		return
+	}
+	clientConn, _, err := hijacker.Hijack()
+	if err != nil {
+		destination.Close()
+		return
+	}
+	clientConn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n"))
+
+	go relay(destination, clientConn)
+	go relay(clientConn, destination)
+}
+
+// relay copies one side of a tunnel to the other and closes both when done
+func relay(dst, src net.Conn) {
+	defer dst.Close()
+	defer src.Close()
+	io.Copy(dst, src)
+}
+
+// removeHopByHop strips connection-scoped headers
+func removeHopByHop(header nethttp.Header) {
+	for _, name := range hopByHopHeaders {
+		header.Del(name)
+	}
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
+)
+
+func TestRecorderReverseProxy(t *testing.T) {
+	t.Setenv(config.SaulHomeEnv, t.TempDir())
+
+	upstream := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path != "/api/users" || r.URL.Query().Get("team") != "core" || r.Header.Get("Authorization") != "Bearer abc" {
+			t.Errorf("upstream got %s %s %v", r.Method, r.URL, r.Header)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(nethttp.StatusCreated)
+		w.Write([]byte(`{"id":1,"echo":` + string(body) + `}`))
+	}))
+	defer upstream.Close()
+
+	base, _ := url.Parse(upstream.URL + "/api")
+	proxy := httptest.NewServer(newRecorder("rec", base))
+	defer proxy.Close()
+
+	send := func(body string) *nethttp.Response {
+		request, _ := nethttp.NewRequest("POST", proxy.URL+"/users?team=core", strings.NewReader(body))
+		request.Header.Set("Authorization", "Bearer abc")
+		request.Header.Set("Content-Type", "application/json")
+		response, err := nethttp.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("request through the recorder failed: %v", err)
+		}
+		return response
+	}
+
+	response := send(`{"name":"alice"}`)
+	relayed, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	if response.StatusCode != nethttp.StatusCreated || string(relayed) != `{"id":1,"echo":{"name":"alice"}}` {
+		t.Errorf("client got %d %s, want the upstream response relayed", response.StatusCode, relayed)
+	}
+
+	// The same endpoint again is relayed but not recorded twice
+	send(`{"name":"bob"}`).Body.Close()
+	if workspace.PresetExists("rec-post-api-users-2") {
+		t.Errorf("a repeated request created a second preset")
+	}
+
+	preset := "rec-post-api-users"
+	request, _ := workspace.LoadPresetFile(preset, "request")
+	if request.Get("method") != "POST" || request.Get("url") != upstream.URL+"/api/users" {
+		t.Errorf("request.toml = %v %v", request.Get("method"), request.Get("url"))
+	}
+	if count, _ := request.Get("history_count").(int64); count != workspace.DefaultImportHistoryCount {
+		t.Errorf("history_count = %v, want %d", request.Get("history_count"), workspace.DefaultImportHistoryCount)
+	}
+	headers, _ := workspace.LoadPresetFile(preset, "headers")
+	if headers.Get("Authorization") != "Bearer abc" || headers.Has("Accept-Encoding") {
+		t.Errorf("headers.toml = %v", headers.Keys())
+	}
+	query, _ := workspace.LoadPresetFile(preset, "query")
+	if query.Get("team") != "core" {
+		t.Errorf("query.team = %v, want core", query.Get("team"))
+	}
+	body, _ := workspace.LoadPresetFile(preset, "body")
+	if body.Get("name") != "alice" {
+		t.Errorf("body.name = %v, want alice", body.Get("name"))
+	}
+
+	history, err := workspace.ListHistoryResponses(preset)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("history = %d entries (%v), want only the recorded response", len(history), err)
+	}
+	first := history[0]
+	if first.Status != "201 Created" || first.Body != `{"id":1,"echo":{"name":"alice"}}` {
+		t.Errorf("history entry = %s %v", first.Status, first.Body)
+	}
+	if first.Request == nil || first.Request.Body != `{"name":"alice"}` || first.Request.Headers["Authorization"] != workspace.RedactedValue {
+		t.Errorf("history request = %+v, want the body and a REDACTED Authorization", first.Request)
+	}
+}
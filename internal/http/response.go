@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-resty/resty/v2"
 	"github.com/tidwall/gjson"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
 	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
 )
@@ -45,8 +46,15 @@ func DisplayResponse(response *resty.Response, rawMode bool, preset string, resp
 					}
 				}
 			} else {
-				// Check if response is too large for TOML conversion
-				if len(filteredBody) > 10000 {
+				// Check if response is too large for TOML conversion, or config.toml asks for JSON
+				if config.Current().OutputMode() == config.OutputJSON {
+					var jsonObj interface{}
+					if err := json.Unmarshal(filteredBody, &jsonObj); err == nil {
+						if prettyJSON, err := json.MarshalIndent(jsonObj, "", "  "); err == nil {
+							content = string(prettyJSON)
+						}
+					}
+				} else if len(filteredBody) > 10000 {
 					content = fmt.Sprintf("Response too large for TOML (%d bytes) - showing JSON:\n", len(filteredBody))
 					var jsonObj interface{}
 					if err := json.Unmarshal(filteredBody, &jsonObj); err == nil {
@@ -155,6 +163,11 @@ func FormatAsToml(jsonData []byte) string {
 
 // applyFiltering applies JSON filtering if filters are configured for the preset
 func applyFiltering(jsonData []byte, preset string) []byte {
+	// One-shot requests have no preset and no filters
+	if preset == "" {
+		return jsonData
+	}
+
 	// Load filters configuration
 	filtersHandler, err := workspace.LoadPresetFile(preset, "filters")
 	if err != nil {
@@ -234,8 +247,10 @@ func FormatResponseContent(jsonData []byte, preset string, rawMode bool) string
 	// Normal mode: apply filtering
 	filteredBody := applyFiltering(jsonData, preset)
 
-	if tomlFormatted := FormatAsToml(filteredBody); tomlFormatted != "" {
-		return tomlFormatted
+	if config.Current().OutputMode() != config.OutputJSON {
+		if tomlFormatted := FormatAsToml(filteredBody); tomlFormatted != "" {
+			return tomlFormatted
+		}
 	}
 
 	var jsonObj interface{}
@@ -1,12 +1,22 @@
 package project
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	nethttp "net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/DeprecatedLuar/better-curl-saul/internal/codegen"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/commands"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
+	saulhttp "github.com/DeprecatedLuar/better-curl-saul/internal/http"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/variables"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/workspace"
 )
@@ -17,9 +27,7 @@ func setupTestPreset(t *testing.T, name string) (string, func()) {
 		t.Fatalf("failed to create temp dir: %v", err)
 	}
 
-	os.Setenv("SAUL_CONFIG_DIR_PATH", tempDir)
-	os.Setenv("SAUL_APP_DIR_NAME", "saul")
-	os.Setenv("SAUL_PRESETS_DIR_NAME", "presets")
+	os.Setenv(config.SaulHomeEnv, tempDir)
 
 	err = workspace.CreatePresetDirectory(name)
 	if err != nil {
@@ -28,9 +36,7 @@ func setupTestPreset(t *testing.T, name string) (string, func()) {
 
 	cleanup := func() {
 		os.RemoveAll(tempDir)
-		os.Unsetenv("SAUL_CONFIG_DIR_PATH")
-		os.Unsetenv("SAUL_APP_DIR_NAME")
-		os.Unsetenv("SAUL_PRESETS_DIR_NAME")
+		os.Unsetenv(config.SaulHomeEnv)
 	}
 
 	return name, cleanup
@@ -524,11 +530,31 @@ func TestImportCurlString(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:    "urlencoded body becomes JSON fields",
+			curlCmd: `curl -sS https://api.example.com/login -H 'Content-Type: application/x-www-form-urlencoded' --data-urlencode 'user=al ice' -d pass=x`,
+			validate: func(t *testing.T, preset string) {
+				bodyHandler, err := workspace.LoadPresetFile(preset, "body")
+				if err != nil {
+					t.Fatalf("LoadPresetFile body failed: %v", err)
+				}
+				if user := bodyHandler.Get("user"); user != "al ice" {
+					t.Errorf("body.user = %v, want 'al ice'", user)
+				}
+				headersHandler, err := workspace.LoadPresetFile(preset, "headers")
+				if err != nil {
+					t.Fatalf("LoadPresetFile headers failed: %v", err)
+				}
+				if contentType := headersHandler.Get("Content-Type"); contentType != nil {
+					t.Errorf("headers.Content-Type = %v, want it dropped", contentType)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := workspace.ImportCurlString(preset, tt.curlCmd)
+			_, err := workspace.ImportCurlString(preset, tt.curlCmd)
 			if err != nil {
 				t.Fatalf("ImportCurlString failed: %v", err)
 			}
@@ -536,4 +562,1563 @@ func TestImportCurlString(t *testing.T) {
 			tt.validate(t, preset)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestImportCurlFile(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "curlfiletest")
+	defer cleanup()
+
+	script := `#!/bin/sh
+export TOKEN=abc
+curl -s https://api.example.com/users -H "Authorization: Bearer $TOKEN" | jq .
+
+$ curl -X POST https://api.example.com/users \
+    --json '{"name":"john; doe"}'
+curl -k https://api.example.com/health && echo ok
+`
+	path := filepath.Join(t.TempDir(), "requests.sh")
+	if err := os.WriteFile(path, []byte(script), 0644); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	result, err := workspace.ImportCurlFile(path, workspace.CurlImportOptions{Prefix: "api"})
+	if err != nil {
+		t.Fatalf("ImportCurlFile failed: %v", err)
+	}
+
+	want := []string{"api-get-users", "api-post-users", "api-get-health"}
+	if len(result.Presets) != len(want) {
+		t.Fatalf("presets = %v, want %v", result.Presets, want)
+	}
+	for i, preset := range want {
+		if result.Presets[i] != preset {
+			t.Errorf("presets[%d] = %s, want %s", i, result.Presets[i], preset)
+		}
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "-k") {
+		t.Errorf("warnings = %v, want one about -k", result.Warnings)
+	}
+
+	headers, _ := workspace.LoadPresetFile("api-get-users", "headers")
+	if auth := headers.Get("Authorization"); auth != "Bearer $TOKEN" {
+		t.Errorf("Authorization = %v, want Bearer $TOKEN", auth)
+	}
+	body, _ := workspace.LoadPresetFile("api-post-users", "body")
+	if name := body.Get("name"); name != "john; doe" {
+		t.Errorf("body.name = %v, want 'john; doe'", name)
+	}
+
+	// A single preset only takes one command
+	if _, err := workspace.ImportCurlString("curlfiletest", script); err == nil {
+		t.Error("ImportCurlString with several commands succeeded, want an error")
+	}
+}
+
+func TestImportHARFile(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "hartest")
+	defer cleanup()
+
+	har := `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1"}, "entries": [
+		{
+			"startedDateTime": "2024-01-15T10:00:00Z",
+			"time": 120,
+			"request": {
+				"method": "POST",
+				"url": "https://api.example.com/users?team=core",
+				"headers": [
+					{"name": ":authority", "value": "api.example.com"},
+					{"name": "Authorization", "value": "Bearer abc"},
+					{"name": "Content-Length", "value": "16"}
+				],
+				"queryString": [{"name": "team", "value": "core"}],
+				"postData": {"mimeType": "application/json", "text": "{\"name\":\"alice\"}"}
+			},
+			"response": {
+				"status": 201, "statusText": "Created",
+				"headers": [{"name": "Content-Type", "value": "application/json"}],
+				"content": {"size": 11, "mimeType": "application/json", "text": "eyJpZCI6IDF9", "encoding": "base64"}
+			}
+		},
+		{
+			"startedDateTime": "2024-01-15T10:00:01Z",
+			"time": 50,
+			"request": {"method": "GET", "url": "https://cdn.example.com/logo.png", "headers": []},
+			"response": {"status": 200, "statusText": "OK", "headers": [], "content": {"size": 0, "mimeType": "image/png"}}
+		}
+	]}}`
+
+	harFile := filepath.Join(t.TempDir(), "capture.har")
+	if err := os.WriteFile(harFile, []byte(har), 0644); err != nil {
+		t.Fatalf("failed to write HAR file: %v", err)
+	}
+
+	result, err := workspace.ImportHARFile(harFile, workspace.HARImportOptions{
+		Filter:      `^api\.example\.com/`,
+		Prefix:      "hartest",
+		WithHistory: true,
+	})
+	if err != nil {
+		t.Fatalf("ImportHARFile failed: %v", err)
+	}
+	if len(result.Presets) != 1 {
+		t.Fatalf("expected 1 preset (filter drops the CDN entry), got %v", result.Presets)
+	}
+	preset := result.Presets[0]
+	defer workspace.DeletePreset(preset)
+
+	if preset != "hartest-post-users" {
+		t.Errorf("preset name = %s, want hartest-post-users", preset)
+	}
+
+	reqHandler, _ := workspace.LoadPresetFile(preset, "request")
+	if url := reqHandler.Get("url"); url != "https://api.example.com/users" {
+		t.Errorf("url = %v, want https://api.example.com/users", url)
+	}
+
+	headersHandler, _ := workspace.LoadPresetFile(preset, "headers")
+	if auth := headersHandler.Get("Authorization"); auth != "Bearer abc" {
+		t.Errorf("Authorization = %v, want Bearer abc", auth)
+	}
+	if headersHandler.Has("Content-Length") || headersHandler.Has(":authority") {
+		t.Error("transport headers should not be imported")
+	}
+
+	queryHandler, _ := workspace.LoadPresetFile(preset, "query")
+	if team := queryHandler.Get("team"); team != "core" {
+		t.Errorf("query.team = %v, want core", team)
+	}
+
+	bodyHandler, _ := workspace.LoadPresetFile(preset, "body")
+	if name := bodyHandler.Get("name"); name != "alice" {
+		t.Errorf("body.name = %v, want alice", name)
+	}
+
+	response, err := workspace.LoadHistoryResponse(preset, 1)
+	if err != nil {
+		t.Fatalf("LoadHistoryResponse failed: %v", err)
+	}
+	if response.Status != "201 Created" {
+		t.Errorf("status = %s, want 201 Created", response.Status)
+	}
+	if response.Body != `{"id": 1}` {
+		t.Errorf("body = %v, want decoded base64 content", response.Body)
+	}
+	if response.Timestamp != "2024-01-15T10:00:00Z" {
+		t.Errorf("timestamp = %s, want original HAR time", response.Timestamp)
+	}
+}
+
+func TestExportHAR(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "harexport")
+	defer cleanup()
+
+	err := workspace.StoreResponse("harexport", workspace.HistoryResponse{
+		Timestamp: "2024-01-15T10:00:00Z",
+		Method:    "POST",
+		URL:       "https://api.example.com/login",
+		Status:    "201 Created",
+		Duration:  "0.250s",
+		Headers:   map[string]interface{}{"Content-Type": "application/json", "Set-Cookie": "sid=abc"},
+		Body:      `{"session_id":"s-123","user":"alice"}`,
+		Request: workspace.NewHistoryRequest(
+			map[string]string{"Authorization": "Bearer abc", "Content-Type": "application/json"},
+			map[string]string{"api_key": "k-1", "page": "2"},
+			`{"token":"t-456","user":"alice"}`,
+		),
+	}, 5)
+	if err != nil {
+		t.Fatalf("StoreResponse failed: %v", err)
+	}
+
+	// Request secrets never reach the history file
+	stored, err := workspace.LoadHistoryResponse("harexport", 1)
+	if err != nil {
+		t.Fatalf("LoadHistoryResponse failed: %v", err)
+	}
+	if stored.Request.Headers["Authorization"] != workspace.RedactedValue || stored.Request.Query["api_key"] != workspace.RedactedValue || strings.Contains(stored.Request.Body, "t-456") {
+		t.Errorf("stored request kept its secrets: %+v", stored.Request)
+	}
+
+	data, err := workspace.ExportHAR([]workspace.HARExportSelection{{Preset: "harexport"}}, true)
+	if err != nil {
+		t.Fatalf("ExportHAR failed: %v", err)
+	}
+	var doc workspace.HARDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("exported HAR is not valid JSON: %v", err)
+	}
+	if doc.Log.Version != "1.2" || doc.Log.Creator.Name != "saul" || len(doc.Log.Entries) != 1 {
+		t.Fatalf("HAR log = version %s, creator %s, %d entries", doc.Log.Version, doc.Log.Creator.Name, len(doc.Log.Entries))
+	}
+
+	entry := doc.Log.Entries[0]
+	if entry.StartedDateTime != "2024-01-15T10:00:00Z" || entry.Time != 250 || entry.Timings.Wait != 250 || entry.Timings.Send != 0 {
+		t.Errorf("entry timing = %s, %v, %+v, want 250ms all waiting", entry.StartedDateTime, entry.Time, entry.Timings)
+	}
+	if entry.Response.Status != 201 || entry.Response.StatusText != "Created" {
+		t.Errorf("status = %d %q, want 201 Created", entry.Response.Status, entry.Response.StatusText)
+	}
+	if entry.Request.Method != "POST" || !strings.HasPrefix(entry.Request.URL, "https://api.example.com/login?") {
+		t.Errorf("request = %s %s", entry.Request.Method, entry.Request.URL)
+	}
+
+	values := func(pairs []workspace.HARNameValue) map[string]string {
+		found := map[string]string{}
+		for _, pair := range pairs {
+			found[pair.Name] = pair.Value
+		}
+		return found
+	}
+	if headers := values(entry.Request.Headers); headers["Authorization"] != workspace.RedactedValue || headers["Content-Type"] != "application/json" {
+		t.Errorf("request headers = %v, want Authorization REDACTED", headers)
+	}
+	if query := values(entry.Request.QueryString); query["api_key"] != workspace.RedactedValue || query["page"] != "2" {
+		t.Errorf("query = %v, want api_key REDACTED", query)
+	}
+	if entry.Request.PostData == nil || entry.Request.PostData.Text != `{"token":"REDACTED","user":"alice"}` {
+		t.Errorf("request body = %+v, want token REDACTED", entry.Request.PostData)
+	}
+	if headers := values(entry.Response.Headers); headers["Set-Cookie"] != workspace.RedactedValue {
+		t.Errorf("response headers = %v, want Set-Cookie REDACTED", headers)
+	}
+	if text := entry.Response.Content.Text; text != `{"session_id":"REDACTED","user":"alice"}` || entry.Response.Content.MimeType != "application/json" {
+		t.Errorf("response content = %q (%s), want session_id REDACTED", text, entry.Response.Content.MimeType)
+	}
+
+	// --no-redact only gives back the response, the request was redacted when stored
+	data, _ = workspace.ExportHAR([]workspace.HARExportSelection{{Preset: "harexport"}}, false)
+	if !strings.Contains(string(data), "s-123") || strings.Contains(string(data), "t-456") {
+		t.Errorf("unredacted export = %s", data)
+	}
+}
+
+func TestImportPostmanCollection(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "pmtest")
+	defer cleanup()
+
+	collection := `{
+		"info": {"name": "Shop API", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"auth": {"type": "bearer", "bearer": [{"key": "token", "value": "{{token}}", "type": "string"}]},
+		"variable": [{"key": "baseUrl", "value": "https://shop.example.com"}],
+		"item": [
+			{
+				"name": "Orders",
+				"item": [
+					{
+						"name": "Create order",
+						"event": [{"listen": "test", "script": {"exec": ["pm.test()"]}}],
+						"request": {
+							"method": "POST",
+							"header": [{"key": "X-Trace", "value": "{{$guid}}"}],
+							"url": {
+								"raw": "{{baseUrl}}/orders?dry=true",
+								"query": [{"key": "dry", "value": "true"}, {"key": "debug", "value": "1", "disabled": true}]
+							},
+							"body": {"mode": "raw", "raw": "{\"item\": \"{{sku}}\", \"qty\": 2}"}
+						}
+					}
+				]
+			},
+			{
+				"name": "Login",
+				"request": {
+					"method": "POST",
+					"auth": {"type": "noauth"},
+					"url": "{{baseUrl}}/login",
+					"body": {"mode": "urlencoded", "urlencoded": [{"key": "user", "value": "bob"}]}
+				}
+			}
+		]
+	}`
+	env := `{"name": "prod", "values": [{"key": "token", "value": "s3cret", "enabled": true}]}`
+
+	dir := t.TempDir()
+	collectionFile := filepath.Join(dir, "collection.json")
+	envFile := filepath.Join(dir, "env.json")
+	os.WriteFile(collectionFile, []byte(collection), 0644)
+	os.WriteFile(envFile, []byte(env), 0644)
+
+	result, err := workspace.ImportPostmanCollection(collectionFile, workspace.PostmanImportOptions{
+		EnvironmentFile: envFile,
+		Prefix:          "pmtest",
+	})
+	if err != nil {
+		t.Fatalf("ImportPostmanCollection failed: %v", err)
+	}
+	for _, preset := range result.Presets {
+		defer workspace.DeletePreset(preset)
+	}
+
+	wantPresets := []string{"pmtest/orders/create-order", "pmtest/login"}
+	if len(result.Presets) != len(wantPresets) {
+		t.Fatalf("presets = %v, want %v", result.Presets, wantPresets)
+	}
+	for i, want := range wantPresets {
+		if result.Presets[i] != want {
+			t.Errorf("preset %d = %s, want %s", i, result.Presets[i], want)
+		}
+	}
+
+	order := wantPresets[0]
+	reqHandler, _ := workspace.LoadPresetFile(order, "request")
+	if url := reqHandler.Get("url"); url != "{@baseUrl}/orders" {
+		t.Errorf("url = %v, want {@baseUrl}/orders", url)
+	}
+	headersHandler, _ := workspace.LoadPresetFile(order, "headers")
+	if auth := headersHandler.Get("Authorization"); auth != "Bearer {@token}" {
+		t.Errorf("Authorization = %v, want inherited bearer auth", auth)
+	}
+	if trace := headersHandler.Get("X-Trace"); trace != "{?guid}" {
+		t.Errorf("X-Trace = %v, want {?guid}", trace)
+	}
+	queryHandler, _ := workspace.LoadPresetFile(order, "query")
+	if queryHandler.Has("debug") {
+		t.Error("disabled query param should not be imported")
+	}
+	bodyHandler, _ := workspace.LoadPresetFile(order, "body")
+	if item := bodyHandler.Get("item"); item != "{@sku}" {
+		t.Errorf("body.item = %v, want {@sku}", item)
+	}
+	varsHandler, _ := workspace.LoadPresetFile(order, "variables")
+	if token := varsHandler.Get("headers.token"); token != "s3cret" {
+		t.Errorf("variables headers.token = %v, want value from environment", token)
+	}
+	if base := varsHandler.Get("request.baseUrl"); base != "https://shop.example.com" {
+		t.Errorf("variables request.baseUrl = %v, want collection variable", base)
+	}
+
+	loginHeaders, _ := workspace.LoadPresetFile("pmtest/login", "headers")
+	if loginHeaders.Has("Authorization") {
+		t.Error("noauth request should not inherit collection auth")
+	}
+	loginBody, _ := workspace.LoadPresetFile("pmtest/login", "body")
+	if user := loginBody.Get("user"); user != "bob" {
+		t.Errorf("login body.user = %v, want bob", user)
+	}
+
+	foundTestScript := false
+	for _, warning := range result.Warnings {
+		if strings.Contains(warning, "test script not imported") {
+			foundTestScript = true
+		}
+	}
+	if !foundTestScript {
+		t.Errorf("expected test script to be reported, warnings: %v", result.Warnings)
+	}
+}
+
+func TestImportInsomniaExport(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "insotest")
+	defer cleanup()
+
+	export := `{"_type": "export", "__export_format": 4, "resources": [
+		{"_id": "wrk_1", "_type": "workspace", "name": "Shop API"},
+		{"_id": "env_base", "_type": "environment", "parentId": "wrk_1", "name": "Base",
+			"data": {"base_url": "https://shop.example.com", "auth": {"token": "dev-token"}}},
+		{"_id": "env_prod", "_type": "environment", "parentId": "env_base", "name": "Production",
+			"data": {"auth": {"token": "prod-token"}}},
+		{"_id": "fld_orders", "_type": "request_group", "parentId": "wrk_1", "name": "Orders"},
+		{"_id": "fld_admin", "_type": "request_group", "parentId": "fld_orders", "name": "Admin"},
+		{"_id": "req_refund", "_type": "request", "parentId": "fld_admin", "name": "Refund order",
+			"method": "post", "url": "{{ _.base_url }}/orders/refund?notify=true",
+			"headers": [
+				{"name": "X-Trace", "value": "{% uuid 'v4' %}"},
+				{"name": "X-Debug", "value": "1", "disabled": true},
+				{"name": "Content-Length", "value": "42"}
+			],
+			"authentication": {"type": "bearer", "token": "{{ _.auth.token }}"},
+			"body": {"mimeType": "application/json", "text": "{\"reason\": \"duplicate\", \"amount\": 10}"}},
+		{"_id": "req_login", "_type": "request", "parentId": "wrk_1", "name": "Login",
+			"method": "POST", "url": "{{ base_url }}/login",
+			"body": {"mimeType": "application/x-www-form-urlencoded", "params": [{"name": "user", "value": "bob"}]}}
+	]}`
+
+	exportFile := filepath.Join(t.TempDir(), "insomnia.json")
+	if err := os.WriteFile(exportFile, []byte(export), 0644); err != nil {
+		t.Fatalf("failed to write Insomnia export: %v", err)
+	}
+
+	if _, err := workspace.ImportInsomniaExport(exportFile, workspace.InsomniaImportOptions{Environment: "Staging"}); err == nil {
+		t.Error("importing with an unknown environment succeeded, want an error")
+	}
+
+	result, err := workspace.ImportInsomniaExport(exportFile, workspace.InsomniaImportOptions{Environment: "Production"})
+	if err != nil {
+		t.Fatalf("ImportInsomniaExport failed: %v", err)
+	}
+
+	// Folders become collections under the workspace name
+	wantPresets := []string{"shop-api/orders/admin/refund-order", "shop-api/login"}
+	if len(result.Presets) != len(wantPresets) || result.Presets[0] != wantPresets[0] || result.Presets[1] != wantPresets[1] {
+		t.Fatalf("presets = %v, want %v", result.Presets, wantPresets)
+	}
+	if collections, _ := workspace.ListCollection("shop-api/orders"); len(collections) != 1 || collections[0] != wantPresets[0] {
+		t.Errorf("ListCollection(shop-api/orders) = %v, want the refund preset", collections)
+	}
+
+	refund := wantPresets[0]
+	reqHandler, _ := workspace.LoadPresetFile(refund, "request")
+	if reqHandler.Get("method") != "POST" || reqHandler.Get("url") != "{@base_url}/orders/refund" {
+		t.Errorf("request = %v %v, want POST {@base_url}/orders/refund", reqHandler.Get("method"), reqHandler.Get("url"))
+	}
+	queryHandler, _ := workspace.LoadPresetFile(refund, "query")
+	if notify := queryHandler.Get("notify"); notify != "true" {
+		t.Errorf("query.notify = %v, want true", notify)
+	}
+	headersHandler, _ := workspace.LoadPresetFile(refund, "headers")
+	if auth := headersHandler.Get("Authorization"); auth != "Bearer {@auth_token}" {
+		t.Errorf("Authorization = %v, want Bearer {@auth_token}", auth)
+	}
+	if trace := headersHandler.Get("X-Trace"); trace != "{?}" {
+		t.Errorf("X-Trace = %v, want the template tag turned into a prompt", trace)
+	}
+	if headersHandler.Has("X-Debug") || headersHandler.Has("Content-Length") {
+		t.Errorf("headers = %v, want disabled and transport headers left out", headersHandler.Keys())
+	}
+	bodyHandler, _ := workspace.LoadPresetFile(refund, "body")
+	if reason := bodyHandler.Get("reason"); reason != "duplicate" {
+		t.Errorf("body.reason = %v, want duplicate", reason)
+	}
+
+	// The sub-environment is layered over the base environment
+	varsHandler, _ := workspace.LoadPresetFile(refund, "variables")
+	if token := varsHandler.Get("headers.auth_token"); token != "prod-token" {
+		t.Errorf("variables headers.auth_token = %v, want the Production value", token)
+	}
+	if base := varsHandler.Get("request.base_url"); base != "https://shop.example.com" {
+		t.Errorf("variables request.base_url = %v, want the base environment value", base)
+	}
+
+	loginBody, _ := workspace.LoadPresetFile("shop-api/login", "body")
+	if user := loginBody.Get("user"); user != "bob" {
+		t.Errorf("login body.user = %v, want bob", user)
+	}
+	warnings := strings.Join(result.Warnings, "\n")
+	if !strings.Contains(warnings, "template tags") || !strings.Contains(warnings, "converted to JSON") {
+		t.Errorf("warnings = %v, want the template tag and form body reported", result.Warnings)
+	}
+}
+
+func TestImportBrunoCollection(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "brutest")
+	defer cleanup()
+
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "users"), 0755)
+	os.MkdirAll(filepath.Join(dir, "environments"), 0755)
+	os.WriteFile(filepath.Join(dir, "bruno.json"), []byte(`{"version": "1", "name": "Team API", "type": "collection"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "collection.bru"), []byte("auth {\n  mode: bearer\n}\n\nauth:bearer {\n  token: {{token}}\n}\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "environments", "dev.bru"), []byte("vars {\n  host: http://localhost:3000\n  token: dev-token\n}\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "users", "Create User.bru"), []byte(`meta {
+  name: Create User
+  type: http
+  seq: 1
+}
+
+post {
+  url: {{host}}/users
+  body: json
+  auth: inherit
+}
+
+params:query {
+  notify: true
+  ~dryRun: 1
+}
+
+headers {
+  X-Team: core
+}
+
+body:json {
+  {
+    "name": "john"
+  }
+}
+
+tests {
+  test("created", function() {});
+}
+`), 0644)
+
+	result, err := workspace.ImportBrunoCollection(dir, workspace.BrunoImportOptions{
+		Environment: "dev",
+		Prefix:      "brutest",
+	})
+	if err != nil {
+		t.Fatalf("ImportBrunoCollection failed: %v", err)
+	}
+	for _, preset := range result.Presets {
+		defer workspace.DeletePreset(preset)
+	}
+
+	if len(result.Presets) != 1 || result.Presets[0] != "brutest/users/create-user" {
+		t.Fatalf("presets = %v, want [brutest/users/create-user]", result.Presets)
+	}
+	preset := result.Presets[0]
+
+	reqHandler, _ := workspace.LoadPresetFile(preset, "request")
+	if method := reqHandler.Get("method"); method != "POST" {
+		t.Errorf("method = %v, want POST", method)
+	}
+	if url := reqHandler.Get("url"); url != "{@host}/users" {
+		t.Errorf("url = %v, want {@host}/users", url)
+	}
+	headersHandler, _ := workspace.LoadPresetFile(preset, "headers")
+	if auth := headersHandler.Get("Authorization"); auth != "Bearer {@token}" {
+		t.Errorf("Authorization = %v, want inherited collection auth", auth)
+	}
+	queryHandler, _ := workspace.LoadPresetFile(preset, "query")
+	if queryHandler.Has("dryRun") {
+		t.Error("disabled query param should not be imported")
+	}
+	bodyHandler, _ := workspace.LoadPresetFile(preset, "body")
+	if name := bodyHandler.Get("name"); name != "john" {
+		t.Errorf("body.name = %v, want john", name)
+	}
+	varsHandler, _ := workspace.LoadPresetFile(preset, "variables")
+	if token := varsHandler.Get("headers.token"); token != "dev-token" {
+		t.Errorf("variables headers.token = %v, want value from environment", token)
+	}
+
+	if len(result.Warnings) == 0 || !strings.Contains(strings.Join(result.Warnings, "\n"), "tests not imported") {
+		t.Errorf("expected tests block to be reported, warnings: %v", result.Warnings)
+	}
+}
+
+func TestImportOpenAPISpec(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "oastest")
+	defer cleanup()
+
+	spec := `openapi: 3.0.3
+info: {title: Pets, version: "1"}
+servers:
+  - url: https://api.pets.io/v1
+security: [{bearerAuth: []}]
+paths:
+  /pets/{petId}:
+    get:
+      operationId: getPet
+      tags: [pets]
+      parameters:
+        - {name: petId, in: path, required: true, schema: {type: string}}
+        - {name: fields, in: query, required: true, schema: {type: string, default: all}}
+        - {name: verbose, in: query, schema: {type: boolean}}
+  /pets:
+    post:
+      operationId: createPet
+      tags: [pets]
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Pet'}
+  /stores:
+    get: {operationId: listStores, tags: [stores]}
+components:
+  securitySchemes:
+    bearerAuth: {type: http, scheme: bearer}
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id: {type: integer, readOnly: true}
+        name: {type: string, example: Rex}
+        age: {type: integer}
+`
+	specFile := filepath.Join(t.TempDir(), "spec.yaml")
+	os.WriteFile(specFile, []byte(spec), 0644)
+
+	result, err := workspace.ImportOpenAPISpec(specFile, workspace.OpenAPIImportOptions{Tag: "pets", Prefix: "oastest"})
+	if err != nil {
+		t.Fatalf("ImportOpenAPISpec failed: %v", err)
+	}
+	for _, preset := range result.Presets {
+		defer workspace.DeletePreset(preset)
+	}
+
+	wantPresets := []string{"oastest-createpet", "oastest-getpet"}
+	if strings.Join(result.Presets, ",") != strings.Join(wantPresets, ",") {
+		t.Fatalf("presets = %v, want %v (untagged operations filtered out)", result.Presets, wantPresets)
+	}
+
+	reqHandler, _ := workspace.LoadPresetFile("oastest-getpet", "request")
+	if url := reqHandler.Get("url"); url != "https://api.pets.io/v1/pets/{?petId}" {
+		t.Errorf("url = %v, want path param as soft variable", url)
+	}
+	queryHandler, _ := workspace.LoadPresetFile("oastest-getpet", "query")
+	if fields := queryHandler.Get("fields"); fields != "all" {
+		t.Errorf("query fields = %v, want default value", fields)
+	}
+	if queryHandler.Has("verbose") {
+		t.Error("optional query params should not be imported")
+	}
+	headersHandler, _ := workspace.LoadPresetFile("oastest-getpet", "headers")
+	if auth := headersHandler.Get("Authorization"); auth != "Bearer {@token}" {
+		t.Errorf("Authorization = %v, want Bearer {@token}", auth)
+	}
+
+	bodyHandler, _ := workspace.LoadPresetFile("oastest-createpet", "body")
+	if name := bodyHandler.Get("name"); name != "Rex" {
+		t.Errorf("body.name = %v, want schema example", name)
+	}
+	if !bodyHandler.Has("age") {
+		t.Error("body skeleton should contain schema properties without examples")
+	}
+	if bodyHandler.Has("id") {
+		t.Error("readOnly properties should not be in the body skeleton")
+	}
+}
+
+func TestOpenAPIValidation(t *testing.T) {
+	spec := `openapi: 3.0.3
+info: {title: Pets, version: "1"}
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      parameters:
+        - {name: limit, in: query, required: true, schema: {type: integer, maximum: 10}}
+        - {name: X-Tenant, in: header, required: true, schema: {type: string}}
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/NewPet'}
+      responses:
+        "201":
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id: {type: integer}
+                  tags: {type: array, items: {type: string}}
+components:
+  schemas:
+    NewPet:
+      type: object
+      required: [name]
+      additionalProperties: false
+      properties:
+        name: {type: string}
+        kind: {type: string, enum: [dog, cat]}
+`
+	specFile := filepath.Join(t.TempDir(), "spec.yaml")
+	os.WriteFile(specFile, []byte(spec), 0644)
+
+	operation, err := workspace.LoadOpenAPIOperation(specFile, "createPet")
+	if err != nil {
+		t.Fatalf("LoadOpenAPIOperation failed: %v", err)
+	}
+	if _, err := workspace.LoadOpenAPIOperation(specFile, "POST /pets"); err != nil {
+		t.Errorf("lookup by method and path failed: %v", err)
+	}
+
+	valid := operation.ValidateRequest(
+		map[string]string{"x-tenant": "acme"},
+		map[string]string{"limit": "5"},
+		[]byte(`{"name": "Rex", "kind": "dog"}`),
+	)
+	if len(valid) != 0 {
+		t.Errorf("valid request reported violations: %v", valid)
+	}
+
+	// Unresolved variables match anything
+	placeholders := operation.ValidateRequest(
+		map[string]string{"X-Tenant": "{@tenant}"},
+		map[string]string{"limit": "{?limit}"},
+		[]byte(`{"name": "{@name}"}`),
+	)
+	if len(placeholders) != 0 {
+		t.Errorf("placeholders reported violations: %v", placeholders)
+	}
+
+	violations := strings.Join(operation.ValidateRequest(
+		map[string]string{},
+		map[string]string{"limit": "50"},
+		[]byte(`{"kind": "bird", "color": "red"}`),
+	), "\n")
+	for _, want := range []string{
+		"query.limit: 50 is above the maximum 10",
+		"header.X-Tenant: required parameter missing",
+		"body.name: required property missing",
+		`body.kind: "bird" is not one of ["dog","cat"]`,
+		"body.color: property not allowed",
+	} {
+		if !strings.Contains(violations, want) {
+			t.Errorf("missing violation %q in:\n%s", want, violations)
+		}
+	}
+
+	responseViolations := strings.Join(operation.ValidateResponse(201, []byte(`{"id": "x", "tags": ["a", 2]}`)), "\n")
+	for _, want := range []string{"response.body.id: expected integer, got string", "response.body.tags[1]: expected string, got integer"} {
+		if !strings.Contains(responseViolations, want) {
+			t.Errorf("missing violation %q in:\n%s", want, responseViolations)
+		}
+	}
+	if undocumented := operation.ValidateResponse(500, nil); len(undocumented) != 1 {
+		t.Errorf("undocumented status should be reported once, got %v", undocumented)
+	}
+}
+
+func TestHTTPFileRoundTrip(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "httptest")
+	defer cleanup()
+
+	httpFile := `@host = https://api.example.com
+@base = {{host}}/v1
+@token = abc123
+
+### List users
+GET {{base}}/users
+    ?page=2
+Authorization: Bearer {{token}}
+
+###
+# @name create-user
+POST {{base}}/users HTTP/1.1
+Content-Type: application/json
+X-Request-Id: {{$guid}}
+
+{"name": "john"}
+
+> {%
+  client.global.set("id", response.body.id);
+%}
+`
+	path := filepath.Join(t.TempDir(), "users.http")
+	os.WriteFile(path, []byte(httpFile), 0644)
+
+	result, err := workspace.ImportHTTPFile(path, workspace.HTTPImportOptions{Prefix: "httptest"})
+	if err != nil {
+		t.Fatalf("ImportHTTPFile failed: %v", err)
+	}
+	for _, preset := range result.Presets {
+		defer workspace.DeletePreset(preset)
+	}
+
+	wantPresets := []string{"httptest-list-users", "httptest-create-user"}
+	if strings.Join(result.Presets, ",") != strings.Join(wantPresets, ",") {
+		t.Fatalf("presets = %v, want %v", result.Presets, wantPresets)
+	}
+
+	reqHandler, _ := workspace.LoadPresetFile("httptest-list-users", "request")
+	if url := reqHandler.Get("url"); url != "{@base}/users" {
+		t.Errorf("url = %v, want {@base}/users", url)
+	}
+	queryHandler, _ := workspace.LoadPresetFile("httptest-list-users", "query")
+	if page := queryHandler.GetAsString("page"); page != "2" {
+		t.Errorf("query page = %v, want multi-line query param", page)
+	}
+	varsHandler, _ := workspace.LoadPresetFile("httptest-list-users", "variables")
+	if base := varsHandler.Get("request.base"); base != "https://api.example.com/v1" {
+		t.Errorf("variables request.base = %v, want nested declaration resolved", base)
+	}
+	headersHandler, _ := workspace.LoadPresetFile("httptest-create-user", "headers")
+	if id := headersHandler.Get("X-Request-Id"); id != "{?guid}" {
+		t.Errorf("X-Request-Id = %v, want {?guid}", id)
+	}
+	if !strings.Contains(strings.Join(result.Warnings, "\n"), "response handler script not imported") {
+		t.Errorf("expected response handler to be reported, warnings: %v", result.Warnings)
+	}
+
+	exported, err := workspace.ExportToHTTP(result.Presets)
+	if err != nil {
+		t.Fatalf("ExportToHTTP failed: %v", err)
+	}
+	for _, want := range []string{
+		"@token = abc123",
+		"### httptest-list-users",
+		"GET {{base}}/users?page=2",
+		"Authorization: Bearer {{token}}",
+		"POST {{base}}/users",
+		`"name": "john"`,
+	} {
+		if !strings.Contains(exported, want) {
+			t.Errorf("export missing %q:\n%s", want, exported)
+		}
+	}
+}
+
+func TestCodegenSnippets(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "codegentest")
+	defer cleanup()
+
+	err := workspace.WritePresetRequest("codegentest", workspace.PresetRequest{
+		Method:  "POST",
+		URL:     "https://api.example.com/users",
+		Headers: map[string]string{"Authorization": "Bearer {@token}"},
+		Query:   map[string]string{"notify": "true"},
+		Body:    `{"name": "john", "admin": false}`,
+	})
+	if err != nil {
+		t.Fatalf("WritePresetRequest failed: %v", err)
+	}
+	defer workspace.DeletePreset("codegentest")
+
+	load := func(target string) *workspace.TomlHandler {
+		handler, _ := workspace.LoadPresetFile("codegentest", target)
+		return handler
+	}
+	request, err := codegen.FromHandlers(load("request"), load("headers"), load("body"), load("query"))
+	if err != nil {
+		t.Fatalf("FromHandlers failed: %v", err)
+	}
+
+	tests := map[string][]string{
+		"go":         {`http.NewRequest("POST"`, `query.Set("notify", "true")`, `req.Header.Set("Authorization", "Bearer {@token}")`},
+		"python":     {"requests.post(url, params=params, headers=headers, json=payload)", `"admin": False`},
+		"js":         {`method: "POST"`, `url.searchParams.set("notify", "true")`, "JSON.stringify("},
+		"httpie":     {"http POST https://api.example.com/users", "notify==true", "admin:=false", "name=john"},
+		"powershell": {"Invoke-RestMethod -Uri 'https://api.example.com/users?notify=true' -Method Post", "'Authorization' = 'Bearer {@token}'"},
+	}
+	for lang, wants := range tests {
+		generator, ok := codegen.Lookup(lang)
+		if !ok {
+			t.Fatalf("no generator registered for %s", lang)
+		}
+		snippet := generator.Generate(request)
+		for _, want := range wants {
+			if !strings.Contains(snippet, want) {
+				t.Errorf("%s snippet missing %q:\n%s", lang, want, snippet)
+			}
+		}
+	}
+
+	if _, ok := codegen.Lookup("cobol"); ok {
+		t.Error("unknown language should not resolve to a generator")
+	}
+}
+
+func TestShellCompletion(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "completetest")
+	defer cleanup()
+
+	err := workspace.WritePresetRequest("completetest", workspace.PresetRequest{
+		Method:  "POST",
+		URL:     "https://api.example.com/users",
+		Headers: map[string]string{"X-Key": "{@apikey}"},
+		Body:    `{"user": {"name": "john"}, "token": "{@token}"}`,
+	})
+	if err != nil {
+		t.Fatalf("WritePresetRequest failed: %v", err)
+	}
+	defer workspace.DeletePreset("completetest")
+
+	tests := []struct {
+		name    string
+		words   []string
+		current string
+		want    []string
+	}{
+		{name: "Preset names", words: []string{"completet"}, want: []string{"completetest"}},
+		{name: "Preset commands", words: []string{"completetest", "g"}, want: []string{"get"}},
+		{name: "Target aliases", words: []string{"completetest", "get", "head"}, want: []string{"header", "headers"}},
+		{name: "Nested body keys", words: []string{"completetest", "edit", "body", ""}, want: []string{"token", "user.name"}},
+		{name: "Current preset keys", words: []string{"get", "headers", ""}, current: "completetest", want: []string{"X-Key"}},
+		{name: "Variable names", words: []string{"completetest", "call", "-v", "token", ""}, want: []string{"apikey", "token"}},
+		{name: "Command flags", words: []string{"completetest", "call", "--d"}, want: []string{"--dry-run"}},
+		{name: "Flag values", words: []string{"completetest", "get", "--format", ""}, want: []string{"curl", "http"}},
+		{name: "Import formats", words: []string{"import", "h"}, want: []string{"har", "http"}},
+		{name: "Files left to the shell", words: []string{"import", "har", ""}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := commands.Complete(tt.words, tt.current)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Complete(%q) = %v, want %v", tt.words, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRmFields(t *testing.T) {
+	preset, cleanup := setupTestPreset(t, "rmtest")
+	defer cleanup()
+	defer workspace.DeletePreset(preset)
+
+	err := workspace.WritePresetRequest(preset, workspace.PresetRequest{
+		URL:     "https://api.example.com",
+		Headers: map[string]string{"Authorization": "Bearer x", "X-Trace": "1"},
+		Body:    `{"user": {"name": "john", "email": "j@x", "address": {"city": "Albuquerque"}}, "tags": ["a"]}`,
+	})
+	if err != nil {
+		t.Fatalf("WritePresetRequest failed: %v", err)
+	}
+
+	rm := func(args ...string) error {
+		cmd, err := core.ParseCommand(append([]string{preset, "rm"}, args...))
+		if err != nil {
+			return err
+		}
+		return commands.Rm(cmd)
+	}
+	load := func(target string) *workspace.TomlHandler {
+		handler, _ := workspace.LoadPresetFile(preset, target)
+		return handler
+	}
+
+	if err := rm("body", "user.email"); err != nil {
+		t.Fatalf("rm body user.email failed: %v", err)
+	}
+	if body := load("body"); body.Has("user.email") || !body.Has("user.name") {
+		t.Errorf("body keys after rm = %v", body.LeafKeys())
+	}
+
+	// Wildcards remove whole subtables, and the emptied parent goes with them
+	if err := rm("body", "user.*"); err != nil {
+		t.Fatalf("rm body user.* failed: %v", err)
+	}
+	if body := load("body"); body.Has("user") || !body.Has("tags") {
+		t.Errorf("body keys after wildcard rm = %v", body.LeafKeys())
+	}
+
+	if err := rm("header", "Authorization"); err != nil {
+		t.Fatalf("rm header failed: %v", err)
+	}
+	if headers := load("headers"); headers.Has("Authorization") || !headers.Has("X-Trace") {
+		t.Errorf("header keys after rm = %v", headers.Keys())
+	}
+
+	// A missing key fails the whole command before anything is saved
+	if err := rm("headers", "X-Trace", "Missing"); err == nil {
+		t.Errorf("rm with a missing key succeeded, want an error")
+	}
+	if !load("headers").Has("X-Trace") {
+		t.Errorf("X-Trace was removed although the command failed")
+	}
+
+	for _, args := range [][]string{{"body"}, {"url", "x"}, {"bdy", "x"}} {
+		if err := rm(args...); err == nil {
+			t.Errorf("rm %v succeeded, want an error", args)
+		}
+	}
+
+	cmd, err := core.ParseCommand([]string{"rm", "preset", "a", "b", "--yes"})
+	if err != nil || cmd.Global != "rm" || !cmd.Yes || strings.Join(cmd.Targets, ",") != "a,b" {
+		t.Errorf("ParseCommand(rm preset a b --yes) = %+v, %v", cmd, err)
+	}
+}
+
+func TestCopyAndMovePreset(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "cptest")
+	defer cleanup()
+	defer workspace.DeletePreset("cptest")
+	defer workspace.DeletePreset("cptest-copy")
+	defer workspace.DeletePreset("cptest-moved")
+
+	err := workspace.WritePresetRequest("cptest", workspace.PresetRequest{URL: "https://api.example.com"})
+	if err != nil {
+		t.Fatalf("WritePresetRequest failed: %v", err)
+	}
+	variablesHandler, _ := workspace.LoadPresetFile("cptest", "variables")
+	variablesHandler.Set("token", "secret")
+	workspace.SavePresetFile("cptest", "variables", variablesHandler)
+	workspace.StoreResponse("cptest", workspace.HistoryResponse{Status: "200 OK"}, 5)
+
+	presetHas := func(preset, name string) bool {
+		presetPath, _ := workspace.GetPresetPath(preset)
+		_, err := os.Stat(filepath.Join(presetPath, name))
+		return err == nil
+	}
+
+	copyCmd := core.Command{Global: "cp", Targets: []string{"cptest", "cptest-copy"}, NoVariables: true}
+	if err := commands.ExecuteCopyCommand(copyCmd); err != nil {
+		t.Fatalf("cp failed: %v", err)
+	}
+	if !presetHas("cptest-copy", "request.toml") || !presetHas("cptest-copy", ".history") || presetHas("cptest-copy", "variables.toml") {
+		t.Errorf("cp --no-variables copied the wrong files")
+	}
+	if !presetHas("cptest", "variables.toml") {
+		t.Errorf("cp touched the source preset")
+	}
+
+	if err := commands.ExecuteCopyCommand(copyCmd); err == nil {
+		t.Errorf("cp onto an existing preset succeeded, want an error")
+	}
+
+	moveCmd := core.Command{Global: "mv", Targets: []string{"cptest", "cptest-moved"}, NoHistory: true}
+	if err := commands.ExecuteCopyCommand(moveCmd); err != nil {
+		t.Fatalf("mv failed: %v", err)
+	}
+	if workspace.PresetExists("cptest") || !presetHas("cptest-moved", "variables.toml") || presetHas("cptest-moved", ".history") {
+		t.Errorf("mv --no-history left the wrong files")
+	}
+
+	// Moving a collection renames the presets inside it for sessions and extends too
+	t.Setenv(core.SessionEnv, "cptest")
+	workspace.WritePresetRequest("cpshop/orders", workspace.PresetRequest{URL: "https://shop.example.com/orders"})
+	workspace.WritePresetRequest("cpclient", workspace.PresetRequest{URL: "https://shop.example.com/client"})
+	request, _ := workspace.LoadPresetFile("cpclient", "request")
+	request.Set(workspace.ExtendsKey, "cpshop/orders")
+	workspace.SavePresetFile("cpclient", "request", request)
+	sm, _ := core.NewSessionManager()
+	sm.SetCurrentPreset("cpshop/orders")
+
+	moveCollection := core.Command{Global: "mv", Targets: []string{"cpshop/", "cpstore"}}
+	if err := commands.ExecuteCopyCommand(moveCollection); err != nil {
+		t.Fatalf("mv collection failed: %v", err)
+	}
+	if !workspace.PresetExists("cpstore/orders") || workspace.PresetExists("cpshop/orders") {
+		t.Errorf("mv collection didn't move the presets inside")
+	}
+	if parent := workspace.ReadExtends("cpclient"); parent != "cpstore/orders" {
+		t.Errorf("extends = %q after moving the collection, want cpstore/orders", parent)
+	}
+	if sm, _ := core.NewSessionManager(); sm.GetCurrentPreset() != "cpstore/orders" {
+		t.Errorf("session preset = %q after moving the collection, want cpstore/orders", sm.GetCurrentPreset())
+	}
+}
+
+func TestOneShotSave(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "existing")
+	defer cleanup()
+
+	upstream := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusOK)
+	}))
+	defer upstream.Close()
+
+	workspace.WritePresetRequest("existing", workspace.PresetRequest{
+		Method:  "POST",
+		URL:     upstream.URL + "/old",
+		Headers: map[string]string{"X-Old": "1"},
+		Body:    `{"secret": "s3cret"}`,
+	})
+
+	// Saving over an existing preset would send its old body and headers along
+	saveCmd := core.Command{Global: "request", Target: "GET", Targets: []string{upstream.URL + "/new"}, Save: "existing"}
+	if err := saulhttp.ExecuteOneShotCommand(saveCmd); err == nil {
+		t.Errorf("--save onto an existing preset succeeded, want an error")
+	}
+	if request, _ := workspace.LoadPresetFile("existing", "request"); request.GetAsString("method") != "POST" || request.GetAsString("url") != upstream.URL+"/old" {
+		t.Errorf("refused --save changed the preset: %s %s", request.GetAsString("method"), request.GetAsString("url"))
+	}
+
+	saveCmd.Save, saveCmd.Preset = "fresh", "fresh"
+	if err := saulhttp.ExecuteOneShotCommand(saveCmd); err != nil {
+		t.Fatalf("--save to a new preset failed: %v", err)
+	}
+	if request, _ := workspace.LoadPresetFile("fresh", "request"); request.GetAsString("method") != "GET" || request.GetAsString("url") != upstream.URL+"/new" {
+		t.Errorf("saved preset = %s %s, want the typed GET", request.GetAsString("method"), request.GetAsString("url"))
+	}
+}
+
+func TestPresetCollections(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "coltest/issues/create")
+	defer cleanup()
+	defer func() {
+		collectionPath, _ := workspace.GetPresetPath("coltest")
+		os.RemoveAll(collectionPath)
+	}()
+
+	shared, _ := workspace.LoadPresetFile("coltest", "headers")
+	shared.Set("Authorization", "Bearer {@token}")
+	shared.Set("X-Org", "acme")
+	workspace.SavePresetFile("coltest", "headers", shared)
+	sharedVariables, _ := workspace.LoadPresetFile("coltest", "variables")
+	sharedVariables.Set("headers.token", "shh")
+	workspace.SavePresetFile("coltest", "variables", sharedVariables)
+
+	err := workspace.WritePresetRequest("coltest/issues/create", workspace.PresetRequest{
+		URL:     "https://api.example.com/issues",
+		Headers: map[string]string{"X-Org": "override"},
+	})
+	if err != nil {
+		t.Fatalf("WritePresetRequest failed: %v", err)
+	}
+	workspace.WritePresetRequest("coltest/issues/list", workspace.PresetRequest{URL: "https://api.example.com/issues"})
+
+	presets, err := workspace.ListCollection("coltest/")
+	if err != nil || strings.Join(presets, ",") != "coltest/issues/create,coltest/issues/list" {
+		t.Errorf("ListCollection(coltest/) = %v, %v", presets, err)
+	}
+	if !workspace.IsCollection("coltest") || workspace.IsCollection("coltest/issues/list") {
+		t.Errorf("IsCollection got collections and presets mixed up")
+	}
+
+	headers, err := workspace.LoadResolvedPresetFile("coltest/issues/create", "headers")
+	if err != nil {
+		t.Fatalf("LoadResolvedPresetFile failed: %v", err)
+	}
+	if headers.GetAsString("Authorization") != "Bearer {@token}" || headers.GetAsString("X-Org") != "override" {
+		t.Errorf("resolved headers = %v, want inherited Authorization and own X-Org", headers.Keys())
+	}
+
+	// Variables used by shared headers belong to every preset underneath
+	found, _ := variables.FindAllVariables("coltest/issues/list")
+	if len(found) != 1 || found[0].Key != "headers.token" {
+		t.Errorf("FindAllVariables = %+v, want the inherited headers.token", found)
+	}
+
+	for _, name := range []string{"../escape", "coltest/.hidden", "coltest//x"} {
+		if err := workspace.ValidatePresetName(name); err == nil {
+			t.Errorf("ValidatePresetName(%q) succeeded, want an error", name)
+		}
+	}
+
+	// Deleting the last presets takes the emptied collection directories with them
+	workspace.DeletePreset("coltest/issues/create")
+	workspace.DeletePreset("coltest/issues/list")
+	if workspace.PresetExists("coltest/issues") {
+		t.Errorf("empty collection coltest/issues was left behind")
+	}
+}
+
+func TestPresetExtends(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "extbase")
+	defer cleanup()
+	defer workspace.DeletePreset("extbase")
+	defer workspace.DeletePreset("extbase-renamed")
+	defer workspace.DeletePreset("extchild")
+
+	err := workspace.WritePresetRequest("extbase", workspace.PresetRequest{
+		Method:  "POST",
+		URL:     "https://api.example.com/base",
+		Headers: map[string]string{"Authorization": "Bearer base", "Accept": "application/json"},
+		Body:    `{"name": "base"}`,
+	})
+	if err != nil {
+		t.Fatalf("WritePresetRequest failed: %v", err)
+	}
+	err = workspace.WritePresetRequest("extchild", workspace.PresetRequest{
+		URL:     "https://api.example.com/child",
+		Headers: map[string]string{"Accept": "text/plain"},
+	})
+	if err != nil {
+		t.Fatalf("WritePresetRequest failed: %v", err)
+	}
+
+	if err := workspace.ValidateExtends("extchild", "extbase"); err != nil {
+		t.Fatalf("ValidateExtends failed: %v", err)
+	}
+	request, _ := workspace.LoadPresetFile("extchild", "request")
+	request.Set(workspace.ExtendsKey, "extbase")
+	request.Delete("method")
+	workspace.SavePresetFile("extchild", "request", request)
+
+	headers, sources, err := workspace.ResolvePresetFile("extchild", "headers")
+	if err != nil {
+		t.Fatalf("ResolvePresetFile failed: %v", err)
+	}
+	if headers.GetAsString("Authorization") != "Bearer base" || headers.GetAsString("Accept") != "text/plain" {
+		t.Errorf("resolved headers = %v, want inherited Authorization and own Accept", headers.Keys())
+	}
+	if sources["Authorization"] != "extbase" || sources["Accept"] != "extchild" {
+		t.Errorf("sources = %v", sources)
+	}
+
+	// A call sends the child's own authorization instead of the parent's Authorization
+	childHeaders, _ := workspace.LoadPresetFile("extchild", "headers")
+	childHeaders.Set("authorization", "Bearer child")
+	workspace.SavePresetFile("extchild", "headers", childHeaders)
+	sent := saulhttp.LoadPresetFile("extchild", "headers")
+	if sent.Has("Authorization") || sent.GetAsString("authorization") != "Bearer child" {
+		t.Errorf("headers sent = %v, want only the child's authorization", sent.Keys())
+	}
+	childHeaders.Delete("authorization")
+	workspace.SavePresetFile("extchild", "headers", childHeaders)
+
+	resolvedRequest, _ := workspace.LoadResolvedPresetFile("extchild", "request")
+	if resolvedRequest.GetAsString("method") != "POST" || resolvedRequest.Has(workspace.ExtendsKey) {
+		t.Errorf("resolved request = %v, want the inherited method and no extends key", resolvedRequest.Keys())
+	}
+
+	// The body belongs to each preset
+	body, _ := workspace.LoadResolvedPresetFile("extchild", "body")
+	if body.Has("name") {
+		t.Errorf("body was inherited through extends")
+	}
+
+	if err := workspace.ValidateExtends("extbase", "extchild"); err == nil {
+		t.Errorf("ValidateExtends accepted a cycle")
+	}
+	if err := workspace.ValidateExtends("extchild", "extmissing"); err == nil {
+		t.Errorf("ValidateExtends accepted a missing preset")
+	}
+
+	moveCmd := core.Command{Global: "mv", Targets: []string{"extbase", "extbase-renamed"}}
+	if err := commands.ExecuteCopyCommand(moveCmd); err != nil {
+		t.Fatalf("mv failed: %v", err)
+	}
+	if got := workspace.ReadExtends("extchild"); got != "extbase-renamed" {
+		t.Errorf("extends after mv = %q, want extbase-renamed", got)
+	}
+
+	// A parent that's gone is an error, not a crash
+	workspace.DeletePreset("extbase-renamed")
+	for _, lang := range []string{"go", "python"} {
+		if err := commands.Get(core.Command{Preset: "extchild", Command: "get", Lang: lang}); err == nil {
+			t.Errorf("get --lang %s with a missing parent should fail", lang)
+		}
+	}
+}
+
+func TestStoreDiscovery(t *testing.T) {
+	root, err := os.MkdirTemp("", "saul-store-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(root)
+	// Resolve symlinks so paths compare equal to what Getwd reports (macOS /tmp)
+	root, _ = filepath.EvalSymlinks(root)
+
+	workingDir, _ := os.Getwd()
+	defer os.Chdir(workingDir)
+	defer os.Unsetenv(config.SaulHomeEnv)
+	t.Setenv(config.XDGConfigHomeEnv, filepath.Join(root, "xdg"))
+
+	nested := filepath.Join(root, "repo", "services", "api")
+	os.MkdirAll(nested, 0755)
+	os.Chdir(nested)
+	os.Unsetenv(config.SaulHomeEnv)
+
+	store, err := config.ResolveStore()
+	if err != nil || store.Source != config.StoreXDG || store.Path != filepath.Join(root, "xdg", "saul") {
+		t.Errorf("without .saul/ ResolveStore = %+v, %v, want the XDG store", store, err)
+	}
+
+	projectStore := filepath.Join(root, "repo", config.ProjectDirName)
+	os.Mkdir(projectStore, 0755)
+	store, _ = config.ResolveStore()
+	if store.Source != config.StoreProject || store.Path != projectStore {
+		t.Errorf("ResolveStore = %+v, want the project store %s", store, projectStore)
+	}
+	presetsPath, _ := config.GetPresetsPath()
+	if presetsPath != filepath.Join(projectStore, "presets") {
+		t.Errorf("GetPresetsPath = %s, want it inside the project store", presetsPath)
+	}
+
+	os.Setenv(config.SaulHomeEnv, filepath.Join(root, "pinned"))
+	store, _ = config.ResolveStore()
+	if store.Source != config.StoreSaulHome || store.Path != filepath.Join(root, "pinned") {
+		t.Errorf("ResolveStore = %+v, want SAUL_HOME to win", store)
+	}
+}
+
+func TestUserConfig(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "cfgtest")
+	defer cleanup()
+
+	if settings := config.Current(); settings.TimeoutSeconds() != config.DefaultTimeoutSeconds || settings.OutputMode() != config.OutputTOML {
+		t.Errorf("without config.toml got %+v, want the built-in defaults", settings)
+	}
+
+	setCmd := core.Command{Global: "config", Target: "set", Targets: []string{"timeout=7", "output=json", "headers.User-Agent=saul-test", "headers.Accept=*/*", "redact=x-tenant"}}
+	if err := commands.ExecuteConfigCommand(setCmd); err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+	settings, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if settings.TimeoutSeconds() != 7 || settings.OutputMode() != config.OutputJSON || settings.Headers["User-Agent"] != "saul-test" {
+		t.Errorf("after config set got %+v", settings)
+	}
+
+	for _, pair := range []string{"color=purple", "history=500", "proxy=nope", "bogus=1", "timeout"} {
+		cmd := core.Command{Global: "config", Target: "set", Targets: []string{pair}}
+		if err := commands.ExecuteConfigCommand(cmd); err == nil {
+			t.Errorf("config set %s succeeded, want an error", pair)
+		}
+	}
+	if config.Current().TimeoutSeconds() != 7 {
+		t.Errorf("a rejected config set changed the saved settings")
+	}
+
+	// Default headers sit under the preset's own, whatever their capitalization
+	headersHandler, _ := workspace.LoadPresetFile("cfgtest", "headers")
+	headersHandler.Set("accept", "application/json")
+	workspace.SavePresetFile("cfgtest", "headers", headersHandler)
+	headers, sources, err := workspace.ResolvePresetFile("cfgtest", "headers")
+	if err != nil {
+		t.Fatalf("ResolvePresetFile failed: %v", err)
+	}
+	if headers.GetAsString("User-Agent") != "saul-test" || sources["User-Agent"] != workspace.ConfigSource {
+		t.Errorf("resolved headers = %v, sources = %v, want User-Agent from config", headers.Keys(), sources)
+	}
+	if headers.Has("Accept") || headers.GetAsString("accept") != "application/json" {
+		t.Errorf("resolved headers = %v, want the preset's accept to replace config's Accept", headers.Keys())
+	}
+
+	if !workspace.IsSensitiveKey("X-Tenant-Id") || workspace.IsSensitiveKey("X-Request-Id") {
+		t.Errorf("IsSensitiveKey ignores the redact list from config.toml")
+	}
+
+	// The history default is written into new presets only, existing ones keep none
+	historyCmd := core.Command{Global: "config", Target: "set", Targets: []string{"history=3"}}
+	if err := commands.ExecuteConfigCommand(historyCmd); err != nil {
+		t.Fatalf("config set history failed: %v", err)
+	}
+	existing, _ := workspace.LoadPresetFile("cfgtest", "request")
+	if existing.Get("history_count") != nil {
+		t.Errorf("history default leaked into an existing preset: %v", existing.Get("history_count"))
+	}
+	created, _ := workspace.LoadPresetFile("cfgnew", "request")
+	if count, _ := created.Get("history_count").(int64); count != 3 {
+		t.Errorf("new preset history_count = %v, want 3", created.Get("history_count"))
+	}
+}
+
+func TestListPresets(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "shop/orders")
+	defer cleanup()
+
+	workspace.WritePresetRequest("shop/orders", workspace.PresetRequest{Method: "POST", URL: "https://shop.example.com/orders", Body: `{"token": "{@token}"}`})
+	workspace.WritePresetRequest("auth", workspace.PresetRequest{URL: "https://auth.example.com/login"})
+	workspace.StoreResponse("auth", workspace.HistoryResponse{Status: "401 Unauthorized", Timestamp: "2026-01-02T10:00:00Z"}, 5)
+	presetPath, _ := workspace.GetPresetPath("auth")
+	os.WriteFile(filepath.Join(presetPath, "meta.toml"), []byte(`tags = ["admin"]`), 0644)
+
+	summaries, err := commands.FindPresets(nil, "", "")
+	if err != nil || len(summaries) != 2 {
+		t.Fatalf("FindPresets = %+v, %v, want both presets", summaries, err)
+	}
+	auth, orders := summaries[0], summaries[1]
+	if auth.Method != "GET" || auth.Status != "401 Unauthorized" || len(auth.Tags) != 1 || auth.Tags[0] != "admin" {
+		t.Errorf("auth summary = %+v", auth)
+	}
+	if orders.Name != "shop/orders" || orders.Method != "POST" || orders.Variables != 1 || orders.CalledAt != "" {
+		t.Errorf("shop/orders summary = %+v", orders)
+	}
+
+	if found, _ := commands.FindPresets([]string{"SHOP"}, "", ""); len(found) != 1 || found[0].Name != "shop/orders" {
+		t.Errorf("text filter found %+v, want shop/orders", found)
+	}
+	if found, _ := commands.FindPresets(nil, "Admin", ""); len(found) != 1 || found[0].Name != "auth" {
+		t.Errorf("tag filter found %+v, want auth", found)
+	}
+	if found, _ := commands.FindPresets(nil, "", "method"); found[0].Name != "auth" {
+		t.Errorf("sort by method put %s first, want auth (GET)", found[0].Name)
+	}
+	if _, err := commands.FindPresets(nil, "", "size"); err == nil {
+		t.Errorf("unknown sort field should fail")
+	}
+
+	// A preset whose parent is gone still shows its own request, marked broken
+	workspace.WritePresetRequest("orphan", workspace.PresetRequest{Method: "DELETE", URL: "https://shop.example.com/orphan"})
+	request, _ := workspace.LoadPresetFile("orphan", "request")
+	request.Set(workspace.ExtendsKey, "missing-parent")
+	workspace.SavePresetFile("orphan", "request", request)
+	if found, _ := commands.FindPresets([]string{"orphan"}, "", ""); len(found) != 1 || found[0].Method != "DELETE" || found[0].URL != "https://shop.example.com/orphan" || found[0].Broken == "" {
+		t.Errorf("orphan summary = %+v, want its own request and a broken mark", found)
+	}
+
+	// Flags the listing doesn't know send ls to the system
+	for args, native := range map[string]bool{"ls": true, "ls github --sort called": true, "ls --json": true, "ls -la": false, "ls -R shop": false} {
+		if got := core.IsNativeListing(strings.Fields(args)); got != native {
+			t.Errorf("IsNativeListing(%q) = %v, want %v", args, got, native)
+		}
+	}
+}
+
+func TestPresetMetaAndSearch(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "refunds/create")
+	defer cleanup()
+
+	workspace.WritePresetRequest("refunds/create", workspace.PresetRequest{Method: "POST", URL: "https://shop.example.com/payments", Body: `{"order": {"id": 5}}`})
+	workspace.WritePresetRequest("refund", workspace.PresetRequest{URL: "https://shop.example.com/status"})
+	workspace.WritePresetRequest("orders", workspace.PresetRequest{URL: "https://shop.example.com/orders"})
+	workspace.StoreResponse("orders", workspace.HistoryResponse{Status: "200 OK", Body: `{"note": "partial refund"}`}, 5)
+
+	setMeta := core.Command{Preset: "refunds/create", Target: "meta", KeyValuePairs: []core.KeyValuePair{
+		{Key: "description", Value: "Creates a refund for an order"},
+		{Key: "tags", Value: "billing, payments"},
+		{Key: "owner", Value: "42"},
+	}}
+	if err := commands.Set(setMeta); err != nil {
+		t.Fatalf("set meta failed: %v", err)
+	}
+	meta := workspace.LoadPresetMeta("refunds/create")
+	if meta.Description != "Creates a refund for an order" || len(meta.Tags) != 2 || meta.Tags[1] != "payments" || meta.Owner != "42" {
+		t.Errorf("meta = %+v", meta)
+	}
+	setMeta.KeyValuePairs = []core.KeyValuePair{{Key: "color", Value: "red"}}
+	if err := commands.Set(setMeta); err == nil {
+		t.Errorf("unknown meta key should be rejected")
+	}
+
+	matches, err := commands.SearchPresets([]string{"refund"}, false)
+	if err != nil || len(matches) != 2 {
+		t.Fatalf("search refund = %+v, %v, want refund and refunds/create", matches, err)
+	}
+	if matches[0].Name != "refund" || matches[1].Name != "refunds/create" || matches[1].Fields[0] != "name" {
+		t.Errorf("ranking = %s %v, %s %v", matches[0].Name, matches[0].Fields, matches[1].Name, matches[1].Fields)
+	}
+
+	if matches, _ := commands.SearchPresets([]string{"order", "billing"}, false); len(matches) != 1 || matches[0].Name != "refunds/create" {
+		t.Errorf("every term has to match somewhere, got %+v", matches)
+	}
+	if matches, _ := commands.SearchPresets([]string{"partial"}, true); len(matches) != 1 || matches[0].Name != "orders" {
+		t.Errorf("history search found %+v, want orders", matches)
+	}
+	if matches, _ := commands.SearchPresets([]string{"partial"}, false); len(matches) != 0 {
+		t.Errorf("history is only searched with --history, got %+v", matches)
+	}
+	if _, err := commands.SearchPresets(nil, false); err == nil {
+		t.Errorf("search without terms should fail")
+	}
+}
+
+func TestPresetBundle(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "shop/orders")
+	defer cleanup()
+
+	workspace.WritePresetRequest("shop/orders", workspace.PresetRequest{URL: "https://shop.example.com/orders", Headers: map[string]string{"X-Api-Key": "{@key}", "Cookie": "session=abc"}})
+	workspace.WritePresetRequest("shop/refunds", workspace.PresetRequest{URL: "https://shop.example.com/refunds"})
+	refunds, _ := workspace.LoadPresetFile("shop/refunds", "request")
+	refunds.Set(workspace.ExtendsKey, "shop/orders")
+	workspace.SavePresetFile("shop/refunds", "request", refunds)
+	shared, _ := workspace.LoadPresetFile("shop", "headers")
+	shared.Set("Authorization", "Bearer secret")
+	workspace.SavePresetFile("shop", "headers", shared)
+	variablesFile, _ := workspace.LoadPresetFile("shop/orders", "variables")
+	variablesFile.Set("key", "hunter2")
+	workspace.SavePresetFile("shop/orders", "variables", variablesFile)
+	workspace.StoreResponse("shop/orders", workspace.HistoryResponse{Status: "200 OK", Body: `{"token": "t0k"}`}, 5)
+
+	presets, err := workspace.ExpandPresetNames([]string{"shop"})
+	if err != nil || len(presets) != 2 {
+		t.Fatalf("ExpandPresetNames = %v, %v, want both shop presets", presets, err)
+	}
+	data, err := workspace.ExportBundle(presets, workspace.BundleExportOptions{WithHistory: true})
+	if err != nil {
+		t.Fatalf("ExportBundle failed: %v", err)
+	}
+	bundlePath := filepath.Join(t.TempDir(), "shop.tar.gz")
+	os.WriteFile(bundlePath, data, 0644)
+
+	// Import into a fresh store that already has a shop/orders
+	os.Setenv(config.SaulHomeEnv, t.TempDir())
+	workspace.WritePresetRequest("shop/orders", workspace.PresetRequest{URL: "https://mine.example.com"})
+	result, err := workspace.ImportBundle(bundlePath, workspace.BundleImportOptions{
+		Resolve: func(string) (string, error) { return workspace.ConflictRename, nil },
+	})
+	if err != nil {
+		t.Fatalf("ImportBundle failed: %v", err)
+	}
+	if strings.Join(result.Presets, ",") != "shop/orders-2,shop/refunds" {
+		t.Errorf("imported %v, want the taken name renamed", result.Presets)
+	}
+	if workspace.ReadExtends("shop/refunds") != "shop/orders-2" {
+		t.Errorf("extends = %q, want it to follow the rename", workspace.ReadExtends("shop/refunds"))
+	}
+
+	headers, _ := workspace.LoadPresetFile("shop/orders-2", "headers")
+	if headers.GetAsString("X-Api-Key") != "{@key}" || headers.GetAsString("Cookie") != workspace.RedactedValue {
+		t.Errorf("headers = %s / %s, want the variable kept and the cookie redacted", headers.GetAsString("X-Api-Key"), headers.GetAsString("Cookie"))
+	}
+	presetPath, _ := workspace.GetPresetPath("shop/orders-2")
+	if _, err := os.Stat(filepath.Join(presetPath, "variables.toml")); err == nil {
+		t.Errorf("stored variable values were shared")
+	}
+	if shared, _ := workspace.LoadPresetFile("shop", "headers"); shared.GetAsString("Authorization") != workspace.RedactedValue {
+		t.Errorf("collection headers weren't bundled and redacted: %q", shared.GetAsString("Authorization"))
+	}
+	history, _ := workspace.ListHistoryResponses("shop/orders-2")
+	if len(history) != 1 || strings.Contains(history[0].Body.(string), "t0k") {
+		t.Errorf("history = %+v, want one redacted response", history)
+	}
+
+	result, _ = workspace.ImportBundle(bundlePath, workspace.BundleImportOptions{
+		Resolve: func(string) (string, error) { return workspace.ConflictSkip, nil },
+	})
+	if len(result.Presets) != 0 {
+		t.Errorf("skip still imported %v", result.Presets)
+	}
+
+	// Without a terminal to ask on, a collision needs --on-conflict
+	importCmd := core.Command{Global: "import", Target: "bundle", Targets: []string{bundlePath}}
+	if err := commands.Import(importCmd); err == nil || workspace.PresetExists("shop/orders-3") {
+		t.Errorf("import bundle without a terminal or --on-conflict should fail, got %v", err)
+	}
+
+	// A bundle from a newer saul is refused
+	var newer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&newer)
+	archive := tar.NewWriter(gzipWriter)
+	manifest := []byte(`{"format": "saul-bundle", "version": 99, "saul_version": "v9.0.0", "presets": []}`)
+	archive.WriteHeader(&tar.Header{Name: "manifest.json", Mode: 0644, Size: int64(len(manifest))})
+	archive.Write(manifest)
+	archive.Close()
+	gzipWriter.Close()
+	os.WriteFile(bundlePath, newer.Bytes(), 0644)
+	if _, err := workspace.ImportBundle(bundlePath, workspace.BundleImportOptions{}); err == nil || !strings.Contains(err.Error(), "v9.0.0") {
+		t.Errorf("a bundle from a newer saul should be refused, got %v", err)
+	}
+
+	// A bad name later in the manifest stops the import before an overwrite removes anything
+	writeBundle := func(presets string, files map[string]string) {
+		var buffer bytes.Buffer
+		gzipWriter := gzip.NewWriter(&buffer)
+		archive := tar.NewWriter(gzipWriter)
+		manifest := []byte(`{"format": "saul-bundle", "version": 1, "saul_version": "v1.0.0", "presets": ` + presets + `}`)
+		archive.WriteHeader(&tar.Header{Name: "manifest.json", Mode: 0644, Size: int64(len(manifest))})
+		archive.Write(manifest)
+		for name, content := range files {
+			archive.WriteHeader(&tar.Header{Name: "presets/" + name, Mode: 0644, Size: int64(len(content))})
+			archive.Write([]byte(content))
+		}
+		archive.Close()
+		gzipWriter.Close()
+		os.WriteFile(bundlePath, buffer.Bytes(), 0644)
+	}
+	workspace.WritePresetRequest("alpha", workspace.PresetRequest{URL: "https://mine.example.com/alpha", Body: `{"keep": "me"}`})
+	overwrite := workspace.BundleImportOptions{Resolve: func(string) (string, error) { return workspace.ConflictOverwrite, nil }}
+	writeBundle(`["alpha", "../evil"]`, map[string]string{"alpha/request.toml": `url = "https://theirs.example.com"`})
+	if _, err := workspace.ImportBundle(bundlePath, overwrite); err == nil {
+		t.Errorf("a bundle with an invalid preset name should be refused")
+	}
+	if request, _ := workspace.LoadPresetFile("alpha", "request"); request.GetAsString("url") != "https://mine.example.com/alpha" {
+		t.Errorf("refused import changed alpha: url = %q", request.GetAsString("url"))
+	}
+
+	writeBundle(`["alpha"]`, map[string]string{"alpha/request.toml": `url = "https://theirs.example.com"`})
+	if _, err := workspace.ImportBundle(bundlePath, overwrite); err != nil {
+		t.Fatalf("overwrite import failed: %v", err)
+	}
+	alphaPath, _ := workspace.GetPresetPath("alpha")
+	if request, _ := workspace.LoadPresetFile("alpha", "request"); request.GetAsString("url") != "https://theirs.example.com" {
+		t.Errorf("overwritten alpha url = %q, want the bundled one", request.GetAsString("url"))
+	}
+	if _, err := os.Stat(filepath.Join(alphaPath, "body.toml")); err == nil {
+		t.Errorf("overwrite kept the old body.toml")
+	}
+	if staged, _ := filepath.Glob(filepath.Join(filepath.Dir(alphaPath), ".import-*")); len(staged) != 0 {
+		t.Errorf("staging directories left behind: %v", staged)
+	}
+}
+
+func TestDeletePresets(t *testing.T) {
+	_, cleanup := setupTestPreset(t, "delbase")
+	defer cleanup()
+	t.Setenv(core.SessionEnv, "deltest")
+
+	workspace.WritePresetRequest("delbase", workspace.PresetRequest{URL: "https://api.example.com"})
+	workspace.WritePresetRequest("delchild", workspace.PresetRequest{URL: "https://api.example.com/child"})
+	request, _ := workspace.LoadPresetFile("delchild", "request")
+	request.Set(workspace.ExtendsKey, "delbase")
+	workspace.SavePresetFile("delchild", "request", request)
+	sm, _ := core.NewSessionManager()
+	sm.SetCurrentPreset("delbase")
+
+	deleteCmd := core.Command{Global: "rm", Targets: []string{"delbase"}}
+	if err := commands.ExecuteDeleteCommand(deleteCmd); err == nil || !workspace.PresetExists("delbase") {
+		t.Errorf("deleting an extended preset without -y should fail, got %v", err)
+	}
+
+	deleteCmd.Yes = true
+	if err := commands.ExecuteDeleteCommand(deleteCmd); err != nil || workspace.PresetExists("delbase") {
+		t.Fatalf("rm preset -y failed: %v", err)
+	}
+	if sm, _ := core.NewSessionManager(); sm.GetCurrentPreset() != "" || len(sm.RecentPresets()) != 0 {
+		t.Errorf("session still has the deleted preset: %q %+v", sm.GetCurrentPreset(), sm.RecentPresets())
+	}
+
+	// Tests run without a terminal, so confirming needs -y
+	if err := commands.ExecuteDeleteCommand(core.Command{Global: "rm", Targets: []string{"delchild"}}); err == nil || !workspace.PresetExists("delchild") {
+		t.Errorf("rm preset without a terminal or -y should fail, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+// Package utils provides shared utility functions for Better-Curl-Saul
+package utils
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// clipboardReaders lists clipboard CLIs in the order they're tried
+var clipboardReaders = [][]string{
+	{"pbpaste"},
+	{"wl-paste", "--no-newline"},
+	{"xclip", "-selection", "clipboard", "-o"},
+	{"xsel", "--clipboard", "--output"},
+	{"termux-clipboard-get"},
+	{"powershell.exe", "-NoProfile", "-Command", "Get-Clipboard"},
+}
+
+// ReadClipboard returns the system clipboard's text using the first clipboard CLI available
+func ReadClipboard() (string, error) {
+	readers := clipboardReaders
+	if runtime.GOOS == "windows" {
+		readers = [][]string{{"powershell", "-NoProfile", "-Command", "Get-Clipboard"}}
+	}
+
+	for _, reader := range readers {
+		// wl-paste only works inside a Wayland session
+		if reader[0] == "wl-paste" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			continue
+		}
+		if _, err := exec.LookPath(reader[0]); err != nil {
+			continue
+		}
+		output, err := exec.Command(reader[0], reader[1:]...).Output()
+		if err != nil {
+			return "", fmt.Errorf("%s failed: %v", reader[0], err)
+		}
+		return string(output), nil
+	}
+	return "", fmt.Errorf("no clipboard tool found (pbpaste, wl-paste, xclip, xsel)")
+}
+
+// StdinIsPiped reports whether stdin is a pipe or file rather than a terminal
+func StdinIsPiped() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice == 0
+}
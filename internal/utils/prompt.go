@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"os"
+	"strings"
+
+	"github.com/chzyer/readline"
+	"golang.org/x/term"
+)
+
+// StdinIsTerminal reports whether there's someone at a terminal to answer a prompt
+// Unlike StdinIsPiped, /dev/null doesn't count
+func StdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// Choose asks for one of choices, typed in full or by first letter
+// Anything else, or no terminal to ask on, picks the first choice
+func Choose(question string, choices []string) string {
+	hints := make([]string, len(choices))
+	for i, choice := range choices {
+		hints[i] = "[" + choice[:1] + "]" + choice[1:]
+	}
+	rl, err := readline.New(question + " " + strings.Join(hints, "/") + ": ")
+	if err != nil {
+		return choices[0]
+	}
+	defer rl.Close()
+
+	answer, err := rl.Readline()
+	if err != nil {
+		return choices[0]
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	for _, choice := range choices {
+		if answer != "" && (answer == choice || answer == choice[:1]) {
+			return choice
+		}
+	}
+	return choices[0]
+}
+
+// Confirm asks a yes/no question, anything but y or yes counts as no
+func Confirm(question string) bool {
+	rl, err := readline.New(question + " [y/N]: ")
+	if err != nil {
+		return false
+	}
+	defer rl.Close()
+
+	answer, err := rl.Readline()
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
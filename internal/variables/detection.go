@@ -53,7 +53,14 @@ func FindAllVariables(preset string) ([]VariableInfo, error) {
 		// Read file content as text
 		content, err := os.ReadFile(filePath)
 		if err != nil {
-			continue // Skip if file doesn't exist
+			content = nil // The collections above may still have this file
+		}
+
+		// Inherited values (extended presets, collection headers, config.toml headers) can hold variables too
+		if shared, err := workspace.LoadInheritedFile(preset, target); err == nil {
+			if sharedContent, err := shared.ToBytes(); err == nil {
+				content = append(append(content, '\n'), sharedContent...)
+			}
 		}
 
 		// Find all variables in this file using regex
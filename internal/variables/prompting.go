@@ -25,6 +25,10 @@ func PromptForVariables(preset string, persist bool) (map[string]string, error)
 	if err != nil {
 		return nil, fmt.Errorf(display.ErrVariableLoadFailed)
 	}
+	sharedVariables, err := workspace.LoadInheritedFile(preset, "variables")
+	if err != nil {
+		return nil, fmt.Errorf(display.ErrVariableLoadFailed)
+	}
 
 	// Find all variables across all TOML files
 	variables, err := FindAllVariables(preset)
@@ -45,7 +49,7 @@ func PromptForVariables(preset string, persist bool) (map[string]string, error)
 			}
 		} else if variable.Type == "hard" {
 			// Hard variables: use stored value if exists, otherwise prompt
-			currentValue = variablesHandler.GetAsString(variable.Key)
+			currentValue = storedValue(variablesHandler, sharedVariables, variable.Key)
 			if !persist && currentValue != "" {
 				// Use existing value without prompting (only if value exists)
 				substitutions[variable.Key] = currentValue
@@ -53,7 +57,7 @@ func PromptForVariables(preset string, persist bool) (map[string]string, error)
 			}
 
 			// Prompting for hard variable with current value
-			currentValue = variablesHandler.GetAsString(variable.Key)
+			currentValue = storedValue(variablesHandler, sharedVariables, variable.Key)
 			if variable.Name != "" {
 				prompt = variable.Name + ": "
 			} else {
@@ -110,6 +114,10 @@ func PromptForSpecificVariables(preset string, variableNames []string, persist b
 	if err != nil {
 		return nil, fmt.Errorf(display.ErrVariableLoadFailed)
 	}
+	sharedVariables, err := workspace.LoadInheritedFile(preset, "variables")
+	if err != nil {
+		return nil, fmt.Errorf(display.ErrVariableLoadFailed)
+	}
 
 	// Find all variables across all TOML files
 	allVariables, err := FindAllVariables(preset)
@@ -141,7 +149,7 @@ func PromptForSpecificVariables(preset string, variableNames []string, persist b
 
 		if variable.Type == "hard" {
 			// Hard variables: use stored value if exists, show for editing
-			currentValue = variablesHandler.GetAsString(variable.Key)
+			currentValue = storedValue(variablesHandler, sharedVariables, variable.Key)
 			if variable.Name != "" {
 				prompt = "@" + variable.Name + ": "
 			} else {
@@ -195,3 +203,11 @@ func PromptForSpecificVariables(preset string, variableNames []string, persist b
 
 	return substitutions, nil
 }
+
+// storedValue reads a hard variable from the preset, falling back to what its collections share
+func storedValue(own, shared *workspace.TomlHandler, key string) string {
+	if value := own.GetAsString(key); value != "" {
+		return value
+	}
+	return shared.GetAsString(key)
+}
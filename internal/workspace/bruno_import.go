@@ -0,0 +1,382 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// bruBlock is one top-level block of a .bru file: "headers { ... }", "body:json { ... }"
+// Dictionary blocks are read through Pairs, text blocks (bodies, scripts) through Text
+type bruBlock struct {
+	Name  string
+	Pairs []bruPair
+	Text  string
+}
+
+type bruPair struct {
+	Key      string
+	Value    string
+	Disabled bool // "~key: value" lines are switched off in Bruno
+}
+
+// bruFile is a parsed .bru document
+type bruFile []bruBlock
+
+var bruBlockStart = regexp.MustCompile(`^([A-Za-z][\w:-]*)\s*([{\[])\s*$`)
+
+// parseBru splits a .bru document into its blocks
+func parseBru(content string) bruFile {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	var file bruFile
+
+	for i := 0; i < len(lines); i++ {
+		match := bruBlockStart.FindStringSubmatch(strings.TrimRight(lines[i], " \t"))
+		if match == nil {
+			continue
+		}
+		closer := "}"
+		if match[2] == "[" {
+			closer = "]"
+		}
+
+		var body []string
+		for i++; i < len(lines) && strings.TrimRight(lines[i], " \t") != closer; i++ {
+			// Block content is indented by two spaces
+			body = append(body, strings.TrimPrefix(lines[i], "  "))
+		}
+
+		block := bruBlock{Name: match[1], Text: strings.TrimSpace(strings.Join(body, "\n"))}
+		for _, line := range body {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			if closer == "]" {
+				// Array blocks (vars:secret [ token, ... ]) list bare names
+				block.Pairs = append(block.Pairs, bruPair{Key: strings.TrimSuffix(line, ",")})
+				continue
+			}
+			disabled := strings.HasPrefix(line, "~")
+			key, value, found := strings.Cut(strings.TrimPrefix(line, "~"), ":")
+			if found {
+				block.Pairs = append(block.Pairs, bruPair{Key: strings.TrimSpace(key), Value: strings.TrimSpace(value), Disabled: disabled})
+			}
+		}
+		file = append(file, block)
+	}
+
+	return file
+}
+
+// block returns the first block with the given name
+func (f bruFile) block(name string) (bruBlock, bool) {
+	for _, block := range f {
+		if block.Name == name {
+			return block, true
+		}
+	}
+	return bruBlock{}, false
+}
+
+// value returns a key from a dictionary block
+func (f bruFile) value(blockName, key string) string {
+	block, _ := f.block(blockName)
+	for _, pair := range block.Pairs {
+		if pair.Key == key && !pair.Disabled {
+			return pair.Value
+		}
+	}
+	return ""
+}
+
+// BrunoImportOptions controls a Bruno collection import
+type BrunoImportOptions struct {
+	Environment string // Name of a file in the collection's environments/ directory
+	Prefix      string // Preset name prefix, defaults to the collection name in bruno.json
+}
+
+// bruScope holds what a folder passes down to its requests: headers and auth from
+// collection.bru / folder.bru
+type bruScope struct {
+	headers map[string]string
+	auth    bruFile // File whose auth block applies to "auth: inherit" requests
+}
+
+var bruMethods = []string{"get", "post", "put", "delete", "patch", "options", "head"}
+
+// ImportBrunoCollection creates one preset per .bru request in a Bruno collection directory
+// Subdirectories are kept as folder hierarchy, environment vars become hard variable values
+func ImportBrunoCollection(dir string, opts BrunoImportOptions) (*ImportResult, error) {
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a Bruno collection directory", dir)
+	}
+
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = filepath.Base(dir)
+		var manifest struct {
+			Name string `json:"name"`
+		}
+		if data, err := os.ReadFile(filepath.Join(dir, "bruno.json")); err == nil && json.Unmarshal(data, &manifest) == nil && manifest.Name != "" {
+			prefix = manifest.Name
+		}
+	}
+
+	result := &ImportResult{}
+	variables := make(map[string]string)
+	if opts.Environment != "" {
+		envPath := filepath.Join(dir, "environments", opts.Environment+".bru")
+		content, err := os.ReadFile(envPath)
+		if err != nil {
+			return nil, fmt.Errorf("environment '%s' not found in %s", opts.Environment, filepath.Join(dir, "environments"))
+		}
+		env := parseBru(string(content))
+		addBruVars(env, "vars", variables)
+		if secrets, ok := env.block("vars:secret"); ok {
+			for _, secret := range secrets.Pairs {
+				result.Warn("environments/"+opts.Environment, fmt.Sprintf("secret '%s' isn't exported by Bruno - you'll be prompted for it", secret.Key))
+			}
+		}
+	}
+
+	scope := bruScope{headers: make(map[string]string)}
+	if content, err := os.ReadFile(filepath.Join(dir, "collection.bru")); err == nil {
+		scope = scope.with(parseBru(string(content)))
+	}
+
+	var requests []ImportedRequest
+	if err := walkBruno(dir, nil, scope, variables, &requests, result); err != nil {
+		return result, err
+	}
+
+	err = WriteCollectionRequests(prefix, requests, result)
+	return result, err
+}
+
+// walkBruno collects requests from a directory and its subdirectories
+func walkBruno(dir string, folders []string, scope bruScope, variables map[string]string, requests *[]ImportedRequest, result *ImportResult) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", dir, err)
+	}
+
+	if content, err := os.ReadFile(filepath.Join(dir, "folder.bru")); err == nil && len(folders) > 0 {
+		scope = scope.with(parseBru(string(content)))
+	}
+
+	// Stable order: Bruno's own seq numbers live inside the files, file names are good enough here
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			if name == "environments" || strings.HasPrefix(name, ".") || name == "node_modules" {
+				continue
+			}
+			if err := walkBruno(filepath.Join(dir, name), append(append([]string{}, folders...), name), scope, variables, requests, result); err != nil {
+				return err
+			}
+			continue
+		}
+		if !strings.HasSuffix(name, ".bru") || name == "folder.bru" || name == "collection.bru" {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %v", name, err)
+		}
+		file := parseBru(string(content))
+
+		requestName := file.value("meta", "name")
+		if requestName == "" {
+			requestName = strings.TrimSuffix(name, ".bru")
+		}
+		imported := ImportedRequest{Folders: folders, Name: requestName}
+
+		request, ok := convertBruRequest(imported.Label(), file, scope, variables, result)
+		if !ok {
+			continue
+		}
+		imported.Request = request
+		*requests = append(*requests, imported)
+	}
+	return nil
+}
+
+// with layers a collection.bru / folder.bru over the current scope
+func (s bruScope) with(file bruFile) bruScope {
+	headers := make(map[string]string)
+	for key, value := range s.headers {
+		headers[key] = value
+	}
+	if block, ok := file.block("headers"); ok {
+		for _, pair := range block.Pairs {
+			if !pair.Disabled {
+				headers[pair.Key] = pair.Value
+			}
+		}
+	}
+
+	auth := s.auth
+	if mode := file.value("auth", "mode"); mode != "" && mode != "inherit" {
+		auth = file
+	}
+	return bruScope{headers: headers, auth: auth}
+}
+
+// convertBruRequest translates one .bru request into the shared preset request model
+func convertBruRequest(label string, file bruFile, scope bruScope, collectionVars map[string]string, result *ImportResult) (PresetRequest, bool) {
+	var method string
+	for _, candidate := range bruMethods {
+		if _, ok := file.block(candidate); ok {
+			method = candidate
+			break
+		}
+	}
+	if method == "" {
+		result.Warn(label, "not an HTTP request (GraphQL or unknown type) - skipped")
+		return PresetRequest{}, false
+	}
+
+	translate := func(text string) string {
+		return translatePlaceholders(label, text, postmanVariablePattern, result)
+	}
+
+	// Request-level vars override environment values for this preset only
+	variables := make(map[string]string)
+	for key, value := range collectionVars {
+		variables[key] = value
+	}
+	addBruVars(file, "vars:pre-request", variables)
+
+	request := PresetRequest{
+		Method:    strings.ToUpper(method),
+		Headers:   make(map[string]string),
+		Variables: variables,
+	}
+	request.URL, request.Query = splitRawURL(translate(file.value(method, "url")))
+
+	// "params:query" in current Bruno, "query" in older collections
+	for _, blockName := range []string{"params:query", "query"} {
+		if block, ok := file.block(blockName); ok {
+			request.Query = make(map[string]string)
+			for _, pair := range block.Pairs {
+				if !pair.Disabled {
+					request.Query[translate(pair.Key)] = translate(pair.Value)
+				}
+			}
+		}
+	}
+
+	for key, value := range scope.headers {
+		request.Headers[key] = translate(value)
+	}
+	if block, ok := file.block("headers"); ok {
+		for _, pair := range block.Pairs {
+			if !pair.Disabled && !IsTransportHeader(pair.Key) {
+				request.Headers[pair.Key] = translate(pair.Value)
+			}
+		}
+	}
+
+	authFile := file
+	authMode := file.value(method, "auth")
+	if authMode == "inherit" && scope.auth != nil {
+		authFile = scope.auth
+		authMode = scope.auth.value("auth", "mode")
+	}
+	applyBruAuth(label, authMode, authFile, &request, translate, result)
+
+	request.Body = convertBruBody(label, file.value(method, "body"), file, translate, result)
+
+	for _, script := range []string{"script:pre-request", "script:post-response", "tests"} {
+		if block, ok := file.block(script); ok && block.Text != "" {
+			result.Warn(label, script+" not imported")
+		}
+	}
+
+	return request, true
+}
+
+// convertBruBody reads the body block matching the request's body mode
+func convertBruBody(label, mode string, file bruFile, translate func(string) string, result *ImportResult) string {
+	switch mode {
+	case "", "none":
+		return ""
+	case "json":
+		block, _ := file.block("body:json")
+		body := translate(block.Text)
+		if body == "" {
+			return ""
+		}
+		if !IsJSONObject([]byte(body)) {
+			result.Warn(label, "JSON body is not an object (or has unquoted placeholders) - not converted")
+			return ""
+		}
+		return body
+	case "formUrlEncoded", "multipartForm":
+		blockName := "body:form-urlencoded"
+		if mode == "multipartForm" {
+			blockName = "body:multipart-form"
+		}
+		block, _ := file.block(blockName)
+		var fields []FormField
+		for _, pair := range block.Pairs {
+			if !pair.Disabled {
+				fields = append(fields, FormField{
+					Name:   pair.Key,
+					Value:  translate(pair.Value),
+					IsFile: strings.HasPrefix(pair.Value, "@file("),
+				})
+			}
+		}
+		return formFieldsToJSON(label, mode, fields, result)
+	default:
+		result.Warn(label, fmt.Sprintf("'%s' body not supported", mode))
+		return ""
+	}
+}
+
+// applyBruAuth maps bearer, basic and API key auth onto headers or query params
+func applyBruAuth(label, mode string, file bruFile, request *PresetRequest, translate func(string) string, result *ImportResult) {
+	switch mode {
+	case "", "none", "inherit":
+		return
+	case "bearer":
+		request.Headers["Authorization"] = "Bearer " + translate(file.value("auth:bearer", "token"))
+	case "basic":
+		username := translate(file.value("auth:basic", "username"))
+		password := translate(file.value("auth:basic", "password"))
+		request.Headers["Authorization"] = basicAuthorization(label, username, password, result)
+	case "apikey":
+		key := translate(file.value("auth:apikey", "key"))
+		value := translate(file.value("auth:apikey", "value"))
+		if strings.EqualFold(file.value("auth:apikey", "placement"), "queryparams") {
+			request.Query[key] = value
+		} else {
+			request.Headers[key] = value
+		}
+	default:
+		result.Warn(label, fmt.Sprintf("'%s' auth not supported", mode))
+	}
+}
+
+// addBruVars copies a vars block into a variable map using saul-compatible names
+func addBruVars(file bruFile, blockName string, variables map[string]string) {
+	block, ok := file.block(blockName)
+	if !ok {
+		return
+	}
+	for _, pair := range block.Pairs {
+		if !pair.Disabled {
+			variables[saulVariableName(pair.Key)] = pair.Value
+		}
+	}
+}
@@ -0,0 +1,525 @@
+package workspace
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/utils"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// A bundle is a .tar.gz of preset files for sharing:
+//
+//	manifest.json
+//	presets/<preset>/request.toml, headers.toml, ...
+//	presets/<collection>/headers.toml, variables.toml  (shared by the presets underneath)
+//
+// Unless asked otherwise it leaves out the response history and the stored variable
+// values, and redacts secrets written straight into headers, query and body
+
+// BundleFormat and BundleVersion identify the archive layout in the manifest
+// BundleVersion goes up when older versions of saul can't read what newer ones write
+const (
+	BundleFormat  = "saul-bundle"
+	BundleVersion = 1
+
+	bundleManifestName = "manifest.json"
+	bundlePresetsDir   = "presets"
+
+	// maxBundleEntryMB caps what a single file may unpack to, so a crafted bundle can't fill memory
+	maxBundleEntryMB = 64
+)
+
+// What to do with a bundled preset whose name is already taken
+const (
+	ConflictRename    = "rename"
+	ConflictOverwrite = "overwrite"
+	ConflictSkip      = "skip"
+)
+
+// ConflictChoices lists the answers to a name collision, the first being the default
+var ConflictChoices = []string{ConflictRename, ConflictOverwrite, ConflictSkip}
+
+// BundleManifest describes a bundle, so the importing saul knows what it's reading
+type BundleManifest struct {
+	Format      string   `json:"format"`
+	Version     int      `json:"version"`
+	SaulVersion string   `json:"saul_version"`
+	Created     string   `json:"created"`
+	Presets     []string `json:"presets"`
+	History     bool     `json:"history"`   // .history is included
+	Variables   bool     `json:"variables"` // variables.toml values are included
+}
+
+// BundleExportOptions controls what goes into a bundle
+type BundleExportOptions struct {
+	WithHistory bool // Include the response history, redacted unless KeepSecrets
+	KeepSecrets bool // Keep variables.toml and literal secrets as they are
+}
+
+// BundleImportOptions controls how a bundle lands in the store
+type BundleImportOptions struct {
+	Prefix string // Collection to import into: --into shop makes api shop/api
+	// Resolve picks a ConflictChoices answer for a preset that already exists,
+	// an error stops the import before anything is written
+	Resolve func(preset string) (string, error)
+}
+
+// redactedFiles are the preset files whose literal secrets get redacted
+var redactedFiles = map[string]bool{"headers.toml": true, "query.toml": true, "body.toml": true}
+
+// ExpandPresetNames turns collection names into the presets underneath, keeping order
+func ExpandPresetNames(names []string) ([]string, error) {
+	var presets []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = NormalizePresetName(name)
+		if err := ValidatePresetName(name); err != nil {
+			return nil, err
+		}
+		expanded := []string{name}
+		if IsCollection(name) {
+			var err error
+			if expanded, err = ListCollection(name); err != nil {
+				return nil, err
+			}
+		} else if !PresetExists(name) {
+			return nil, fmt.Errorf(display.ErrPresetNotFound, name)
+		}
+		for _, preset := range expanded {
+			if !seen[preset] {
+				seen[preset] = true
+				presets = append(presets, preset)
+			}
+		}
+	}
+	return presets, nil
+}
+
+// ExportBundle packs presets, and the collection files they share, into a .tar.gz
+func ExportBundle(presets []string, opts BundleExportOptions) ([]byte, error) {
+	manifest := BundleManifest{
+		Format:      BundleFormat,
+		Version:     BundleVersion,
+		SaulVersion: utils.Version,
+		Created:     time.Now().UTC().Format(time.RFC3339),
+		Presets:     presets,
+		History:     opts.WithHistory,
+		Variables:   opts.KeepSecrets,
+	}
+
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	archive := tar.NewWriter(gzipWriter)
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := addBundleFile(archive, bundleManifestName, manifestData); err != nil {
+		return nil, err
+	}
+
+	collections := make(map[string]bool)
+	for _, preset := range presets {
+		if err := addBundleDirectory(archive, preset, true, opts); err != nil {
+			return nil, err
+		}
+		for _, collection := range collectionAncestors(preset) {
+			collections[collection] = true
+		}
+	}
+	var sharedCollections []string
+	for collection := range collections {
+		sharedCollections = append(sharedCollections, collection)
+	}
+	sort.Strings(sharedCollections)
+	for _, collection := range sharedCollections {
+		if err := addBundleDirectory(archive, collection, false, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// addBundleDirectory adds the files of a preset or collection directory
+// Child presets are bundled on their own, so subdirectories other than .history are skipped
+func addBundleDirectory(archive *tar.Writer, name string, isPreset bool, opts BundleExportOptions) error {
+	presetPath, err := GetPresetPath(name)
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(presetPath)
+	if err != nil {
+		return fmt.Errorf(display.ErrDirectoryFailed)
+	}
+	for _, entry := range entries {
+		entryName := entry.Name()
+		archivePath := path.Join(bundlePresetsDir, name, entryName)
+		switch {
+		case entry.IsDir() && entryName == ".history" && isPreset && opts.WithHistory:
+			if err := addBundleHistory(archive, name, archivePath, opts); err != nil {
+				return err
+			}
+			continue
+		case entry.IsDir(), strings.HasPrefix(entryName, "."):
+			continue
+		case entryName == "variables.toml" && !opts.KeepSecrets:
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(presetPath, entryName))
+		if err != nil {
+			return fmt.Errorf(display.ErrFileLoadFailed, entryName)
+		}
+		if redactedFiles[entryName] && !opts.KeepSecrets {
+			if data, err = redactTomlSecrets(data); err != nil {
+				return fmt.Errorf(display.ErrFileLoadFailed, filepath.Join(name, entryName))
+			}
+		}
+		if err := addBundleFile(archive, archivePath, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addBundleHistory adds a preset's stored responses, redacted unless secrets are kept
+func addBundleHistory(archive *tar.Writer, preset, archivePath string, opts BundleExportOptions) error {
+	historyPath, err := GetHistoryPath(preset)
+	if err != nil {
+		return err
+	}
+	files, err := getHistoryFiles(historyPath)
+	if err != nil {
+		return err
+	}
+	for _, fileName := range files {
+		data, err := os.ReadFile(filepath.Join(historyPath, fileName))
+		if err != nil {
+			continue
+		}
+		if !opts.KeepSecrets {
+			var response HistoryResponse
+			if json.Unmarshal(data, &response) != nil {
+				continue // Can't vouch for what's in a corrupted file
+			}
+			if data, err = json.MarshalIndent(redactHistoryResponse(response), "", "  "); err != nil {
+				return err
+			}
+		}
+		if err := addBundleFile(archive, path.Join(archivePath, fileName), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// redactTomlSecrets replaces literal values of sensitive keys, variables like {@token} stay
+func redactTomlSecrets(data []byte) ([]byte, error) {
+	handler, err := NewTomlHandlerFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range handler.LeafKeys() {
+		value := handler.GetAsString(key)
+		if IsSensitiveKey(key) && !strings.HasPrefix(value, "{@") && !strings.HasPrefix(value, "{?") {
+			handler.Set(key, RedactedValue)
+		}
+	}
+	return handler.ToBytes()
+}
+
+// redactHistoryResponse strips secrets from a stored exchange before it leaves the machine
+func redactHistoryResponse(response HistoryResponse) HistoryResponse {
+	response.Headers = RedactMap(historyHeaders(response.Headers))
+	if body, isText := response.Body.(string); isText {
+		response.Body = RedactJSON(body)
+	}
+	if response.Request != nil {
+		request := *response.Request
+		request.Headers = RedactMap(request.Headers)
+		request.Query = RedactMap(request.Query)
+		request.Body = RedactJSON(request.Body)
+		response.Request = &request
+	}
+	return response
+}
+
+// addBundleFile writes one regular file into the archive
+func addBundleFile(archive *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{Name: name, Mode: int64(config.FilePermissions), Size: int64(len(data)), ModTime: time.Now()}
+	if err := archive.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := archive.Write(data)
+	return err
+}
+
+// readBundle unpacks a bundle into its manifest and files keyed by path under presets/
+func readBundle(bundlePath string) (*BundleManifest, map[string][]byte, error) {
+	file, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf(display.ErrFileLoadFailed, bundlePath)
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf(display.ErrBundleInvalid, bundlePath, err)
+	}
+	archive := tar.NewReader(gzipReader)
+
+	var manifest *BundleManifest
+	files := make(map[string][]byte)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf(display.ErrBundleInvalid, bundlePath, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		limit := int64(maxBundleEntryMB) << 20
+		data, err := io.ReadAll(io.LimitReader(archive, limit+1))
+		if err != nil {
+			return nil, nil, fmt.Errorf(display.ErrBundleInvalid, bundlePath, err)
+		}
+		if int64(len(data)) > limit {
+			return nil, nil, fmt.Errorf(display.ErrBundleEntryTooLarge, bundlePath, header.Name, maxBundleEntryMB)
+		}
+		if header.Name == bundleManifestName {
+			manifest = &BundleManifest{}
+			if err := json.Unmarshal(data, manifest); err != nil {
+				return nil, nil, fmt.Errorf(display.ErrBundleInvalid, bundlePath, err)
+			}
+			continue
+		}
+		if name, inPresets := strings.CutPrefix(path.Clean(header.Name), bundlePresetsDir+"/"); inPresets {
+			files[name] = data
+		}
+	}
+
+	if manifest == nil || manifest.Format != BundleFormat {
+		return nil, nil, fmt.Errorf(display.ErrBundleInvalid, bundlePath, "no saul manifest")
+	}
+	if manifest.Version > BundleVersion {
+		return nil, nil, fmt.Errorf(display.ErrBundleTooNew, manifest.SaulVersion, utils.Version)
+	}
+	return manifest, files, nil
+}
+
+// ImportBundle writes the presets of a bundle into the store
+// Collisions go through opts.Resolve, and extends between bundled presets follows renames
+func ImportBundle(bundlePath string, opts BundleImportOptions) (*ImportResult, error) {
+	manifest, files, err := readBundle(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := NormalizePresetName(opts.Prefix)
+	target := func(name string) string {
+		if prefix == "" {
+			return name
+		}
+		return prefix + "/" + name
+	}
+
+	// Every name is checked before anything is asked or touched
+	for _, preset := range manifest.Presets {
+		if err := ValidatePresetName(target(NormalizePresetName(preset))); err != nil {
+			return nil, err
+		}
+	}
+
+	// Work out every preset's destination first, extends needs the full picture
+	result := &ImportResult{}
+	renamed := make(map[string]string)
+	overwrite := make(map[string]bool)
+	taken := make(map[string]bool)
+	unique := func(name string) string {
+		candidate := name
+		for i := 2; taken[candidate] || PresetExists(candidate); i++ {
+			candidate = fmt.Sprintf("%s-%d", name, i)
+		}
+		return candidate
+	}
+	for _, preset := range manifest.Presets {
+		preset = NormalizePresetName(preset)
+		destination := target(preset)
+		if PresetExists(destination) {
+			choice := ConflictRename
+			if opts.Resolve != nil {
+				if choice, err = opts.Resolve(destination); err != nil {
+					return nil, err // Nothing touched yet
+				}
+			}
+			switch choice {
+			case ConflictSkip:
+				result.Warn(destination, "already exists, skipped")
+				continue
+			case ConflictOverwrite:
+				// A collection here would take its presets down with it, so it gets renamed instead
+				if !IsCollection(destination) {
+					overwrite[destination] = true
+					break
+				}
+				destination = unique(destination)
+			default:
+				destination = unique(destination)
+			}
+		} else if taken[destination] {
+			destination = unique(destination)
+		}
+		taken[destination] = true
+		renamed[preset] = destination
+	}
+
+	// Bundled presets are written into a staging directory and only moved into place,
+	// replacing what they overwrite, once all of them made it to disk
+	presetsDir, err := config.GetPresetsPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(presetsDir, config.DirPermissions); err != nil {
+		return nil, fmt.Errorf(display.ErrDirectoryFailed)
+	}
+	staging, err := os.MkdirTemp(presetsDir, ".import-*")
+	if err != nil {
+		return nil, fmt.Errorf(display.ErrDirectoryFailed)
+	}
+	defer os.RemoveAll(staging)
+
+	// Outer presets first, so a bundled collection preset lands before the ones inside it
+	destinations := make([]string, 0, len(renamed))
+	for _, destination := range renamed {
+		destinations = append(destinations, destination)
+	}
+	sort.Strings(destinations)
+	stagingPath := func(destination string) string {
+		return filepath.Join(staging, strconv.Itoa(slices.Index(destinations, destination)))
+	}
+
+	sharedFiles := make(map[string][]byte)
+	for name, data := range files {
+		dir, fileName := path.Split(name)
+		dir = strings.TrimSuffix(dir, "/")
+		historyFile := false
+		if strings.HasSuffix(dir, "/.history") {
+			dir, historyFile = strings.TrimSuffix(dir, "/.history"), true
+		}
+		if err := ValidatePresetName(dir); err != nil || strings.HasPrefix(fileName, ".") {
+			result.Warn(name, "not a preset file, left out")
+			continue
+		}
+
+		destination, isPreset := renamed[dir]
+		if !isPreset {
+			if !slices.Contains(manifest.Presets, dir) && !historyFile {
+				sharedFiles[target(dir)+"/"+fileName] = data
+			}
+			continue // Skipped on collision, or shared collection files written after the presets
+		}
+
+		destinationPath := stagingPath(destination)
+		if historyFile {
+			destinationPath = filepath.Join(destinationPath, ".history")
+		}
+		if err := os.MkdirAll(destinationPath, config.DirPermissions); err != nil {
+			return nil, fmt.Errorf(display.ErrDirectoryFailed)
+		}
+		if err := os.WriteFile(filepath.Join(destinationPath, fileName), data, config.FilePermissions); err != nil {
+			return nil, fmt.Errorf(display.ErrFileSaveFailed, fileName)
+		}
+	}
+
+	for _, destination := range destinations {
+		if err := os.MkdirAll(stagingPath(destination), config.DirPermissions); err != nil {
+			return result, fmt.Errorf(display.ErrDirectoryFailed)
+		}
+		destinationPath, err := GetPresetPath(destination)
+		if err != nil {
+			return result, err
+		}
+		if overwrite[destination] {
+			if err := os.RemoveAll(destinationPath); err != nil {
+				return result, fmt.Errorf(display.ErrDirectoryFailed)
+			}
+		}
+		if err := os.MkdirAll(filepath.Dir(destinationPath), config.DirPermissions); err != nil {
+			return result, fmt.Errorf(display.ErrDirectoryFailed)
+		}
+		if err := os.Rename(stagingPath(destination), destinationPath); err != nil {
+			return result, fmt.Errorf(display.ErrDirectoryFailed)
+		}
+	}
+
+	// Shared collection files never replace what's already there
+	sharedNames := make([]string, 0, len(sharedFiles))
+	for name := range sharedFiles {
+		sharedNames = append(sharedNames, name)
+	}
+	sort.Strings(sharedNames)
+	for _, name := range sharedNames {
+		collection, fileName := path.Split(name)
+		collectionPath, err := GetPresetPath(strings.TrimSuffix(collection, "/"))
+		if err != nil {
+			return result, err
+		}
+		if _, err := os.Stat(filepath.Join(collectionPath, fileName)); err == nil {
+			result.Warn(collection, fileName+" already exists, kept yours")
+			continue
+		}
+		if err := os.MkdirAll(collectionPath, config.DirPermissions); err != nil {
+			return result, fmt.Errorf(display.ErrDirectoryFailed)
+		}
+		if err := utils.AtomicWriteFile(filepath.Join(collectionPath, fileName), sharedFiles[name], config.FilePermissions); err != nil {
+			return result, fmt.Errorf(display.ErrFileSaveFailed, fileName)
+		}
+	}
+
+	for _, preset := range manifest.Presets {
+		destination, imported := renamed[NormalizePresetName(preset)]
+		if !imported {
+			continue
+		}
+		result.Presets = append(result.Presets, destination)
+		if err := seedHistoryDefault(destination); err != nil {
+			return result, err
+		}
+		if parent, bundled := renamed[ReadExtends(destination)]; bundled && parent != ReadExtends(destination) {
+			handler, err := LoadPresetFile(destination, "request")
+			if err != nil {
+				return result, err
+			}
+			handler.Set(ExtendsKey, parent)
+			if err := SavePresetFile(destination, "request", handler); err != nil {
+				return result, err
+			}
+		}
+	}
+	return result, nil
+}
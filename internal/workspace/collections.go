@@ -0,0 +1,110 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// Presets can live in collections: github/issues/create is presets/github/issues/create.
+// A directory with presets under it is a collection, and its headers.toml and
+// variables.toml are shared by every preset underneath
+
+// NormalizePresetName trims the slashes around a preset or collection name (github/ -> github)
+func NormalizePresetName(name string) string {
+	return strings.Trim(filepath.ToSlash(name), "/")
+}
+
+// ValidatePresetName rejects names that would leave the presets directory or hide in it
+func ValidatePresetName(name string) error {
+	for _, segment := range strings.Split(filepath.ToSlash(name), "/") {
+		if segment == "" || strings.HasPrefix(segment, ".") {
+			return fmt.Errorf(display.ErrPresetNameInvalid, name)
+		}
+	}
+	return nil
+}
+
+// IsCollection reports whether a name is a directory holding other presets
+func IsCollection(name string) bool {
+	presetPath, err := GetPresetPath(name)
+	if err != nil {
+		return false
+	}
+	entries, err := os.ReadDir(presetPath)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// ListCollection lists the presets under a collection, with their full names
+func ListCollection(collection string) ([]string, error) {
+	presetsDir, err := config.GetPresetsPath()
+	if err != nil {
+		return nil, err
+	}
+	root := presetsDir
+	if collection = NormalizePresetName(collection); collection != "" {
+		if root, err = GetPresetPath(collection); err != nil {
+			return nil, err
+		}
+	}
+
+	var presets []string
+	err = filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() || path == root {
+			return nil
+		}
+		if strings.HasPrefix(entry.Name(), ".") {
+			return filepath.SkipDir
+		}
+		name, _ := filepath.Rel(presetsDir, path)
+		if name = filepath.ToSlash(name); !IsCollection(name) {
+			presets = append(presets, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(display.ErrDirectoryFailed)
+	}
+	return presets, nil
+}
+
+// collectionAncestors lists the collections above a preset, outermost first
+// github/issues/create -> github, github/issues
+func collectionAncestors(preset string) []string {
+	segments := strings.Split(NormalizePresetName(preset), "/")
+	var ancestors []string
+	for i := 1; i < len(segments); i++ {
+		ancestors = append(ancestors, strings.Join(segments[:i], "/"))
+	}
+	return ancestors
+}
+
+// pruneEmptyCollections removes the collection directories a deleted or moved preset left empty
+func pruneEmptyCollections(preset string) {
+	ancestors := collectionAncestors(preset)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		collectionPath, err := GetPresetPath(ancestors[i])
+		if err != nil {
+			return
+		}
+		// os.Remove refuses non-empty directories, which is exactly where to stop
+		if os.Remove(collectionPath) != nil {
+			return
+		}
+	}
+}
@@ -10,17 +10,17 @@ import (
 // Variables are preserved as-is ({@token}, {?name}) for documentation/sharing
 func ExportToCurl(preset string) (string, error) {
 	// Load all TOML files
-	requestHandler, err := LoadPresetFile(preset, "request")
+	requestHandler, err := LoadResolvedPresetFile(preset, "request")
 	if err != nil {
 		return "", fmt.Errorf("failed to load request: %v", err)
 	}
 
-	headersHandler, err := LoadPresetFile(preset, "headers")
+	headersHandler, err := LoadResolvedPresetFile(preset, "headers")
 	if err != nil {
 		return "", fmt.Errorf("failed to load headers: %v", err)
 	}
 
-	queryHandler, err := LoadPresetFile(preset, "query")
+	queryHandler, err := LoadResolvedPresetFile(preset, "query")
 	if err != nil {
 		return "", fmt.Errorf("failed to load query: %v", err)
 	}
@@ -1,104 +1,172 @@
 package workspace
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 
 	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
 	"github.com/DeprecatedLuar/better-curl-saul/internal/core"
 )
 
 // ImportCurlString converts a curl command string into TOML preset files
-func ImportCurlString(preset string, curlCmd string) error {
+// Options a preset can't reproduce come back as warnings in the result
+func ImportCurlString(preset string, curlCmd string) (*ImportResult, error) {
 	// Parse curl command
-	result, err := core.ParseCurl(curlCmd)
+	requests, err := core.ParseCurlCommands(curlCmd)
 	if err != nil {
-		return fmt.Errorf(display.ErrCurlParseFailed, err)
+		return nil, fmt.Errorf(display.ErrCurlParseFailed, err)
 	}
+	if len(requests) == 0 {
+		return nil, fmt.Errorf(display.ErrCurlParseFailed, "command must start with 'curl'")
+	}
+	if len(requests) > 1 {
+		return nil, fmt.Errorf(display.ErrCurlMultipleCommands, len(requests))
+	}
+	result := requests[0]
 
 	// Validate URL exists
 	if result.URL == "" {
-		return fmt.Errorf(display.ErrNoCurlURL)
+		return nil, fmt.Errorf(display.ErrNoCurlURL)
 	}
 
-	// Ensure preset directory exists
-	err = CreatePresetDirectory(preset)
-	if err != nil && !os.IsExist(err) {
-		return fmt.Errorf("failed to create preset directory: %v", err)
+	report := &ImportResult{}
+	if err := WritePresetRequest(preset, CurlToPresetRequest(preset, result, report)); err != nil {
+		return nil, err
 	}
+	report.Presets = append(report.Presets, preset)
+	return report, nil
+}
 
-	// Convert body (JSON → TOML)
-	if result.Body != "" {
-		bodyHandler, err := NewTomlHandlerFromJSON([]byte(result.Body))
-		if err != nil {
-			return fmt.Errorf("invalid JSON body: %v", err)
-		}
-		err = SavePresetFile(preset, "body", bodyHandler)
-		if err != nil {
-			return fmt.Errorf("failed to save body: %v", err)
-		}
+// CurlImportOptions controls how a file of curl commands becomes presets
+type CurlImportOptions struct {
+	Prefix string // Prepended to every generated preset name
+}
+
+// ImportCurlFile creates one preset per curl command in a file, "-" reads stdin
+func ImportCurlFile(path string, opts CurlImportOptions) (*ImportResult, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
 	}
 
-	// Convert headers
-	if len(result.Headers) > 0 {
-		headersHandler, err := LoadPresetFile(preset, "headers")
-		if err != nil {
-			return fmt.Errorf("failed to load headers file: %v", err)
+	requests, err := core.ParseCurlCommands(string(data))
+	if err != nil {
+		return nil, fmt.Errorf(display.ErrCurlParseFailed, err)
+	}
+	if len(requests) == 0 {
+		return nil, fmt.Errorf(display.ErrCurlParseFailed, "no curl commands found")
+	}
+
+	result := &ImportResult{}
+	for i, request := range requests {
+		if request.URL == "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("skipped curl command #%d without a URL", i+1))
+			continue
 		}
-		for key, val := range result.Headers {
-			headersHandler.Set(key, val)
+		path := request.BaseURL
+		if parsedURL, err := url.Parse(request.BaseURL); err == nil {
+			path = parsedURL.Path
 		}
-		err = SavePresetFile(preset, "headers", headersHandler)
+
+		imported := ImportedRequest{Name: request.Method + " " + path}
+		imported.Request = CurlToPresetRequest(imported.Label(), request, result)
+		preset, err := WriteImportedRequest(opts.Prefix, imported)
 		if err != nil {
-			return fmt.Errorf("failed to save headers: %v", err)
+			return result, err
 		}
+		result.Presets = append(result.Presets, preset)
 	}
+	return result, nil
+}
 
-	// Convert query params
-	if len(result.Query) > 0 {
-		queryHandler, err := LoadPresetFile(preset, "query")
-		if err != nil {
-			return fmt.Errorf("failed to load query file: %v", err)
+// CurlToPresetRequest converts a parsed curl command into the shared importer model
+// Form and urlencoded bodies become JSON fields, other non-JSON bodies are dropped with a warning
+func CurlToPresetRequest(label string, result *core.CurlRequest, report *ImportResult) PresetRequest {
+	for _, warning := range result.Warnings {
+		report.Warn(label, warning)
+	}
+
+	request := PresetRequest{
+		Method:  result.Method,
+		URL:     result.BaseURL,
+		Headers: result.Headers,
+		Query:   result.Query,
+	}
+
+	switch {
+	case len(result.Form) > 0:
+		fields := make([]FormField, len(result.Form))
+		for i, field := range result.Form {
+			fields[i] = FormField{Name: field.Name, Value: field.Value, IsFile: field.IsFile}
 		}
-		for key, val := range result.Query {
-			queryHandler.Set(key, val)
+		request.Body = formFieldsToJSON(label, "multipart", fields, report)
+		dropContentType(request.Headers)
+	case result.Body == "" || json.Valid([]byte(result.Body)):
+		request.Body = result.Body
+	default:
+		values, err := url.ParseQuery(result.Body)
+		if err != nil || !strings.Contains(result.Body, "=") {
+			report.Warn(label, "body isn't JSON or form data, left out - saul sends bodies as JSON")
+			break
 		}
-		err = SavePresetFile(preset, "query", queryHandler)
-		if err != nil {
-			return fmt.Errorf("failed to save query: %v", err)
+		var fields []FormField
+		for _, key := range sortedQueryKeys(values) {
+			fields = append(fields, FormField{Name: key, Value: values.Get(key)})
 		}
+		request.Body = formFieldsToJSON(label, "urlencoded", fields, report)
+		dropContentType(request.Headers)
 	}
 
-	// Convert request (method, baseURL without query params)
-	requestHandler, err := LoadPresetFile(preset, "request")
-	if err != nil {
-		return fmt.Errorf("failed to load request file: %v", err)
-	}
-	requestHandler.Set("method", result.Method)
-	requestHandler.Set("url", result.BaseURL)
-	err = SavePresetFile(preset, "request", requestHandler)
-	if err != nil {
-		return fmt.Errorf("failed to save request: %v", err)
+	return request
+}
+
+// dropContentType removes a form Content-Type once the body has been turned into JSON
+func dropContentType(headers map[string]string) {
+	for name := range headers {
+		if strings.EqualFold(name, "Content-Type") {
+			delete(headers, name)
+		}
 	}
+}
 
-	return nil
+func sortedQueryKeys(values url.Values) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
-// ImportCurlViaEditor opens an editor for user to paste curl command, then imports it
-func ImportCurlViaEditor(preset string) error {
+// ReadCurlViaEditor opens an editor for user to paste curl command and returns what was pasted
+func ReadCurlViaEditor(preset string) (string, error) {
 	// Create temp file with clear naming convention
 	tempFile, err := os.CreateTemp("", fmt.Sprintf("saul-%s-*.txt", preset))
 	if err != nil {
-		return fmt.Errorf(display.ErrTempFileCreate)
+		return "", fmt.Errorf(display.ErrTempFileCreate)
 	}
 	tempPath := tempFile.Name()
 	tempFile.Close()
 	defer os.Remove(tempPath)
 
-	// Get editor from environment or use nano as fallback
-	editor := os.Getenv("EDITOR")
+	// Get editor from config.toml or the environment, nano as fallback
+	editor := config.Current().Editor
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
 	if editor == "" {
 		editor = "nano"
 	}
@@ -111,21 +179,13 @@ func ImportCurlViaEditor(preset string) error {
 
 	err = cmd.Run()
 	if err != nil {
-		return fmt.Errorf(display.ErrEditorFailed, err)
+		return "", fmt.Errorf(display.ErrEditorFailed, err)
 	}
 
 	// Read content from temp file
 	content, err := os.ReadFile(tempPath)
 	if err != nil {
-		return fmt.Errorf(display.ErrTempFileRead)
+		return "", fmt.Errorf(display.ErrTempFileRead)
 	}
-
-	// Validate content is not empty
-	curlCmd := strings.TrimSpace(string(content))
-	if curlCmd == "" {
-		return fmt.Errorf(display.ErrEmptyCurlCommand)
-	}
-
-	// Import using existing function
-	return ImportCurlString(preset, curlCmd)
-}
\ No newline at end of file
+	return string(content), nil
+}
@@ -19,9 +19,8 @@ func LoadPresetFile(preset, fileType string) (*TomlHandler, error) {
 	}
 
 	// Ensure preset directory exists
-	err = os.MkdirAll(presetPath, config.DirPermissions)
-	if err != nil {
-		return nil, fmt.Errorf(display.ErrDirectoryFailed)
+	if err := ensurePresetDirectory(preset, presetPath); err != nil {
+		return nil, err
 	}
 
 	filePath := filepath.Join(presetPath, fileType+".toml")
@@ -37,6 +36,24 @@ func LoadPresetFile(preset, fileType string) (*TomlHandler, error) {
 	return NewTomlHandler(filePath)
 }
 
+// ReadPresetFile loads a preset's own file for reading, without creating it
+// Returns nil when the preset has no such file or it can't be parsed
+func ReadPresetFile(preset, fileType string) *TomlHandler {
+	presetPath, err := GetPresetPath(preset)
+	if err != nil {
+		return nil
+	}
+	filePath := filepath.Join(presetPath, fileType+".toml")
+	if _, err := os.Stat(filePath); err != nil {
+		return nil
+	}
+	handler, err := NewTomlHandler(filePath)
+	if err != nil {
+		return nil
+	}
+	return handler
+}
+
 // SavePresetFile saves a TOML handler to a specific preset file
 func SavePresetFile(preset, fileType string, handler *TomlHandler) error {
 	presetPath, err := GetPresetPath(preset)
@@ -51,7 +68,7 @@ func SavePresetFile(preset, fileType string, handler *TomlHandler) error {
 
 // ValidateFileType checks if the file type is valid
 func ValidateFileType(fileType string) bool {
-	validTypes := []string{"headers", "body", "query", "request", "variables", "filters"}
+	validTypes := []string{"headers", "body", "query", "request", "variables", "filters", MetaFileType}
 	for _, valid := range validTypes {
 		if strings.ToLower(fileType) == valid {
 			return true
@@ -0,0 +1,214 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/utils"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// HARExportSelection picks which history responses of a preset go into a HAR export
+type HARExportSelection struct {
+	Preset  string
+	Numbers []int // 1 = most recent, empty exports the whole history
+}
+
+// ExportHAR builds a HAR 1.2 document from preset history
+// Presets without history are skipped, secrets are replaced with REDACTED when redact is set
+// Stored requests were redacted by NewHistoryRequest already, redact adds the responses
+func ExportHAR(selections []HARExportSelection, redact bool) ([]byte, error) {
+	doc := HARDocument{Log: HARLog{
+		Version: "1.2",
+		Creator: HARCreator{Name: "saul", Version: utils.Version},
+		Entries: []HAREntry{},
+	}}
+
+	for _, selection := range selections {
+		if !PresetExists(selection.Preset) {
+			return nil, fmt.Errorf(display.ErrPresetNotFound, selection.Preset)
+		}
+
+		responses, err := selectHistoryResponses(selection)
+		if err != nil {
+			return nil, err
+		}
+		for _, response := range responses {
+			doc.Log.Entries = append(doc.Log.Entries, historyToHAREntry(response, redact))
+		}
+	}
+
+	if len(doc.Log.Entries) == 0 {
+		return nil, fmt.Errorf("no history to export - call the preset with history enabled first")
+	}
+
+	// Devtools expect entries in the order they happened
+	sort.SliceStable(doc.Log.Entries, func(i, j int) bool {
+		return doc.Log.Entries[i].StartedDateTime < doc.Log.Entries[j].StartedDateTime
+	})
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// selectHistoryResponses loads the requested responses, or the whole history if none were requested
+func selectHistoryResponses(selection HARExportSelection) ([]HistoryResponse, error) {
+	if len(selection.Numbers) == 0 {
+		return ListHistoryResponses(selection.Preset)
+	}
+
+	var responses []HistoryResponse
+	for _, number := range selection.Numbers {
+		response, err := LoadHistoryResponse(selection.Preset, number)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *response)
+	}
+	return responses, nil
+}
+
+// historyToHAREntry converts a stored response (and its request, when stored) into a HAR entry
+func historyToHAREntry(response HistoryResponse, redact bool) HAREntry {
+	var requestHeaders, query map[string]string
+	var requestBody string
+	if response.Request != nil {
+		requestHeaders = response.Request.Headers
+		query = response.Request.Query
+		requestBody = response.Request.Body
+	}
+	responseHeaders := historyHeaders(response.Headers)
+	responseBody, _ := response.Body.(string)
+
+	if redact {
+		requestHeaders = RedactMap(requestHeaders)
+		query = RedactMap(query)
+		requestBody = RedactJSON(requestBody)
+		responseHeaders = RedactMap(responseHeaders)
+		responseBody = RedactJSON(responseBody)
+	}
+
+	elapsed := 0.0
+	if duration, err := time.ParseDuration(response.Duration); err == nil {
+		elapsed = float64(duration.Microseconds()) / 1000
+	}
+
+	statusCode, statusText := splitStatus(response.Status)
+
+	entry := HAREntry{
+		StartedDateTime: response.Timestamp,
+		Time:            elapsed,
+		Request: HARRequest{
+			Method:      response.Method,
+			URL:         urlWithQuery(response.URL, query),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []HARNameValue{},
+			Headers:     toHARNameValues(requestHeaders),
+			QueryString: toHARNameValues(query),
+			HeadersSize: -1,
+			BodySize:    len(requestBody),
+		},
+		Response: HARResponse{
+			Status:      statusCode,
+			StatusText:  statusText,
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []HARNameValue{},
+			Headers:     toHARNameValues(responseHeaders),
+			Content: HARContent{
+				Size:     len(responseBody),
+				MimeType: headerValue(responseHeaders, "Content-Type"),
+				Text:     responseBody,
+			},
+			HeadersSize: -1,
+			BodySize:    len(responseBody),
+		},
+		// Only the total duration is stored, so it is all attributed to waiting
+		Timings: HARTimings{Send: 0, Wait: elapsed, Receive: 0},
+	}
+
+	if requestBody != "" {
+		mimeType := headerValue(requestHeaders, "Content-Type")
+		if mimeType == "" {
+			mimeType = "application/json"
+		}
+		entry.Request.PostData = &HARPostData{MimeType: mimeType, Text: requestBody}
+	}
+	if response.Request == nil {
+		entry.Comment = "request headers and body were not stored for this response"
+	}
+
+	return entry
+}
+
+// historyHeaders converts the loosely typed stored headers back into a string map
+func historyHeaders(headers interface{}) map[string]string {
+	result := make(map[string]string)
+	switch h := headers.(type) {
+	case map[string]interface{}:
+		for key, value := range h {
+			result[key] = fmt.Sprintf("%v", value)
+		}
+	case map[string]string:
+		for key, value := range h {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// toHARNameValues converts a map into a HAR name/value list in stable key order
+func toHARNameValues(values map[string]string) []HARNameValue {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	list := make([]HARNameValue, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, HARNameValue{Name: key, Value: values[key]})
+	}
+	return list
+}
+
+// headerValue looks up a header case-insensitively
+func headerValue(headers map[string]string, name string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// splitStatus splits "201 Created" into 201 and "Created"
+func splitStatus(status string) (int, string) {
+	parts := strings.SplitN(strings.TrimSpace(status), " ", 2)
+	code, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, status
+	}
+	if len(parts) == 2 {
+		return code, parts[1]
+	}
+	return code, ""
+}
+
+// urlWithQuery appends query params to a base URL
+func urlWithQuery(baseURL string, query map[string]string) string {
+	if len(query) == 0 {
+		return baseURL
+	}
+	params := url.Values{}
+	for key, value := range query {
+		params.Set(key, value)
+	}
+	if strings.Contains(baseURL, "?") {
+		return baseURL + "&" + params.Encode()
+	}
+	return baseURL + "?" + params.Encode()
+}
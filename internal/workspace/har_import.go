@@ -0,0 +1,158 @@
+package workspace
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"time"
+)
+
+// HARImportOptions controls which HAR entries become presets and how
+type HARImportOptions struct {
+	Filter      string // Regex matched against host+path, empty imports everything
+	Prefix      string // Prepended to every generated preset name
+	WithHistory bool   // Store each entry's recorded response as the first history entry
+}
+
+// ImportHARFile creates one preset per entry of a HAR file
+func ImportHARFile(path string, opts HARImportOptions) (*ImportResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	var doc HARDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid HAR file: %v", err)
+	}
+
+	var filter *regexp.Regexp
+	if opts.Filter != "" {
+		filter, err = regexp.Compile(opts.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --filter regex: %v", err)
+		}
+	}
+
+	result := &ImportResult{}
+	for _, entry := range doc.Log.Entries {
+		parsedURL, err := url.Parse(entry.Request.URL)
+		if err != nil || parsedURL.Host == "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("skipped entry with unusable URL %q", entry.Request.URL))
+			continue
+		}
+		if filter != nil && !filter.MatchString(parsedURL.Host+parsedURL.Path) {
+			continue
+		}
+
+		imported := ImportedRequest{Name: entry.Request.Method + " " + parsedURL.Path}
+		imported.Request = harEntryToPresetRequest(entry, parsedURL, imported.Label(), result)
+		request := imported.Request
+
+		preset, err := WriteImportedRequest(opts.Prefix, imported)
+		if err != nil {
+			return result, err
+		}
+		result.Presets = append(result.Presets, preset)
+
+		if opts.WithHistory && entry.Response.Status != 0 {
+			if err := storeHARResponse(preset, request, entry); err != nil {
+				result.Warn(preset, fmt.Sprintf("couldn't store response in history: %v", err))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// harEntryToPresetRequest converts the request half of a HAR entry
+func harEntryToPresetRequest(entry HAREntry, parsedURL *url.URL, label string, result *ImportResult) PresetRequest {
+	request := PresetRequest{
+		Method:  entry.Request.Method,
+		URL:     parsedURL.Scheme + "://" + parsedURL.Host + parsedURL.Path,
+		Headers: make(map[string]string),
+		Query:   make(map[string]string),
+	}
+
+	for _, header := range entry.Request.Headers {
+		if !IsTransportHeader(header.Name) {
+			request.Headers[header.Name] = header.Value
+		}
+	}
+
+	// Prefer the decoded queryString list, fall back to the URL itself
+	if len(entry.Request.QueryString) > 0 {
+		for _, param := range entry.Request.QueryString {
+			if _, exists := request.Query[param.Name]; !exists {
+				request.Query[param.Name] = param.Value
+			}
+		}
+	} else {
+		for key, values := range parsedURL.Query() {
+			if len(values) > 0 {
+				request.Query[key] = values[0]
+			}
+		}
+	}
+
+	if postData := entry.Request.PostData; postData != nil && postData.Text != "" {
+		if IsJSONObject([]byte(postData.Text)) {
+			request.Body = postData.Text
+		} else {
+			result.Warn(label, postData.MimeType+" body not converted (only JSON objects fit body.toml)")
+		}
+	}
+
+	return request
+}
+
+// storeHARResponse saves the recorded response of a HAR entry into the preset's history
+func storeHARResponse(preset string, request PresetRequest, entry HAREntry) error {
+	if err := EnableHistory(preset, DefaultImportHistoryCount); err != nil {
+		return err
+	}
+
+	headers := make(map[string]string)
+	for _, header := range entry.Response.Headers {
+		if _, exists := headers[header.Name]; !exists {
+			headers[header.Name] = header.Value
+		}
+	}
+
+	var body interface{}
+	if text := entry.Response.Content.Text; text != "" {
+		if entry.Response.Content.Encoding == "base64" {
+			if decoded, err := base64.StdEncoding.DecodeString(text); err == nil {
+				text = string(decoded)
+			}
+		}
+		body = text
+	}
+
+	timestamp := ""
+	if started, err := time.Parse(time.RFC3339, entry.StartedDateTime); err == nil {
+		timestamp = started.Format(time.RFC3339)
+	}
+
+	return StoreResponse(preset, HistoryResponse{
+		Timestamp: timestamp,
+		Method:    entry.Request.Method,
+		URL:       request.URL,
+		Status:    fmt.Sprintf("%d %s", entry.Response.Status, entry.Response.StatusText),
+		Duration:  fmt.Sprintf("%.3fs", entry.Time/1000),
+		Headers:   headers,
+		Body:      body,
+		Request:   NewHistoryRequest(request.Headers, request.Query, requestBodyText(entry)),
+	}, DefaultImportHistoryCount)
+}
+
+// requestBodyText returns the raw post data of a HAR entry, if any
+func requestBodyText(entry HAREntry) string {
+	if entry.Request.PostData == nil {
+		return ""
+	}
+	return entry.Request.PostData.Text
+}
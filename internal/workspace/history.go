@@ -16,13 +16,34 @@ import (
 
 // HistoryResponse represents a stored response with metadata
 type HistoryResponse struct {
-	Timestamp string      `json:"timestamp"`
-	Method    string      `json:"method"`
-	URL       string      `json:"url"`
-	Status    string      `json:"status"`
-	Duration  string      `json:"duration"`
-	Headers   interface{} `json:"headers"`
-	Body      interface{} `json:"body"`
+	Timestamp string          `json:"timestamp"`
+	Method    string          `json:"method"`
+	URL       string          `json:"url"`
+	Status    string          `json:"status"`
+	Duration  string          `json:"duration"`
+	Headers   interface{}     `json:"headers"`
+	Body      interface{}     `json:"body"`
+	Request   *HistoryRequest `json:"request,omitempty"` // Missing on responses stored by older versions
+
+	// OpenAPI violations found when the call was made (request and response)
+	Violations []string `json:"violations,omitempty"`
+}
+
+// HistoryRequest is the request that produced a stored response (after variable substitution)
+type HistoryRequest struct {
+	Headers map[string]string `json:"headers,omitempty"`
+	Query   map[string]string `json:"query,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// NewHistoryRequest records a request for history with its secrets already REDACTED
+// History sits in plain files next to the preset, so tokens never reach the disk
+func NewHistoryRequest(headers, query map[string]string, body string) *HistoryRequest {
+	return &HistoryRequest{
+		Headers: RedactMap(headers),
+		Query:   RedactMap(query),
+		Body:    RedactJSON(body),
+	}
 }
 
 // GetHistoryPath returns the full path to a preset's history directory
@@ -67,8 +88,10 @@ func StoreResponse(preset string, response HistoryResponse, historyCount int) er
 		return err
 	}
 
-	// Add timestamp to response
-	response.Timestamp = time.Now().Format(time.RFC3339)
+	// Add timestamp to response (imported responses keep their original time)
+	if response.Timestamp == "" {
+		response.Timestamp = time.Now().Format(time.RFC3339)
+	}
 
 	// Get existing files and handle rotation
 	files, err := getHistoryFiles(historyPath)
@@ -0,0 +1,361 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The .http format used by the VS Code REST Client and JetBrains HTTP Client:
+//
+//	@host = https://api.example.com
+//
+//	### Create user
+//	# @name create-user
+//	POST {{host}}/users?notify=true
+//	Content-Type: application/json
+//
+//	{"name": "john"}
+
+// saulVariablePattern matches {@name}, {?name} and their bare forms
+var saulVariablePattern = regexp.MustCompile(`\{[@?](\w*)\}`)
+
+// toHTTPPlaceholders rewrites saul variables as {{name}}, bare ones as {{variable}}
+func toHTTPPlaceholders(text string) string {
+	return saulVariablePattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := saulVariablePattern.FindStringSubmatch(match)[1]
+		if name == "" {
+			name = "variable"
+		}
+		return "{{" + name + "}}"
+	})
+}
+
+// ExportToHTTP renders presets as one .http file
+// Hard variables with stored values become @name = value declarations at the top
+func ExportToHTTP(presets []string) (string, error) {
+	declarations := make(map[string]string)
+	var blocks []string
+
+	for _, preset := range presets {
+		block, err := presetToHTTPBlock(preset, declarations)
+		if err != nil {
+			return "", err
+		}
+		blocks = append(blocks, block)
+	}
+
+	var out strings.Builder
+	if len(declarations) > 0 {
+		names := make([]string, 0, len(declarations))
+		for name := range declarations {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(&out, "@%s = %s\n", name, declarations[name])
+		}
+		out.WriteString("\n")
+	}
+	out.WriteString(strings.Join(blocks, "\n"))
+	return out.String(), nil
+}
+
+// presetToHTTPBlock renders a single preset as a ### request block
+func presetToHTTPBlock(preset string, declarations map[string]string) (string, error) {
+	if !PresetExists(preset) {
+		return "", fmt.Errorf("preset '%s' does not exist", preset)
+	}
+
+	requestHandler, err := LoadResolvedPresetFile(preset, "request")
+	if err != nil {
+		return "", fmt.Errorf("failed to load request: %v", err)
+	}
+	headersHandler, err := LoadResolvedPresetFile(preset, "headers")
+	if err != nil {
+		return "", fmt.Errorf("failed to load headers: %v", err)
+	}
+	queryHandler, err := LoadResolvedPresetFile(preset, "query")
+	if err != nil {
+		return "", fmt.Errorf("failed to load query: %v", err)
+	}
+	bodyHandler, err := LoadPresetFile(preset, "body")
+	if err != nil {
+		return "", fmt.Errorf("failed to load body: %v", err)
+	}
+	variablesHandler, err := LoadPresetFile(preset, "variables")
+	if err != nil {
+		return "", fmt.Errorf("failed to load variables: %v", err)
+	}
+
+	method := strings.ToUpper(requestHandler.GetAsString("method"))
+	if method == "" {
+		method = "GET"
+	}
+	requestURL := requestHandler.GetAsString("url")
+	if requestURL == "" {
+		return "", fmt.Errorf("preset '%s' has no URL configured", preset)
+	}
+
+	// Query values stay readable: placeholders must survive, so only spaces and separators are escaped
+	var query []string
+	for _, key := range queryHandler.Keys() {
+		query = append(query, escapeHTTPQuery(key)+"="+escapeHTTPQuery(queryHandler.GetAsString(key)))
+	}
+	if len(query) > 0 {
+		separator := "?"
+		if strings.Contains(requestURL, "?") {
+			separator = "&"
+		}
+		requestURL += separator + strings.Join(query, "&")
+	}
+
+	var lines []string
+	lines = append(lines, "### "+preset, "# @name "+preset, method+" "+toHTTPPlaceholders(requestURL))
+
+	hasContentType := false
+	for _, key := range headersHandler.Keys() {
+		if strings.EqualFold(key, "Content-Type") {
+			hasContentType = true
+		}
+		lines = append(lines, key+": "+toHTTPPlaceholders(headersHandler.GetAsString(key)))
+	}
+
+	if len(bodyHandler.Keys()) > 0 {
+		body, err := bodyHandler.ToJSONPretty()
+		if err != nil {
+			return "", fmt.Errorf("failed to convert body to JSON: %v", err)
+		}
+		if !hasContentType {
+			lines = append(lines, "Content-Type: application/json")
+		}
+		lines = append(lines, "", toHTTPPlaceholders(string(body)))
+	}
+
+	// variables.toml keeps values per target ([headers] token = "..."), .http files have one namespace
+	if data, err := variablesHandler.ToJSON(); err == nil {
+		var stored map[string]interface{}
+		json.Unmarshal(data, &stored)
+		for _, values := range stored {
+			for name, value := range asMap(values) {
+				if _, exists := declarations[name]; !exists {
+					declarations[name] = fmt.Sprintf("%v", value)
+				}
+			}
+		}
+	}
+
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// escapeHTTPQuery escapes a query component while keeping {{placeholders}} intact
+func escapeHTTPQuery(value string) string {
+	var escaped strings.Builder
+	last := 0
+	for _, match := range saulVariablePattern.FindAllStringIndex(value, -1) {
+		escaped.WriteString(url.QueryEscape(value[last:match[0]]))
+		escaped.WriteString(value[match[0]:match[1]])
+		last = match[1]
+	}
+	escaped.WriteString(url.QueryEscape(value[last:]))
+	return escaped.String()
+}
+
+// HTTPImportOptions controls a .http file import
+type HTTPImportOptions struct {
+	Prefix string // Preset name prefix, defaults to the file name
+}
+
+var (
+	httpVariableDeclaration = regexp.MustCompile(`^@([\w.-]+)\s*=\s*(.*)$`)
+	httpNameDirective       = regexp.MustCompile(`^(?:#|//)\s*@name\s+(.+)$`)
+	httpRequestLine         = regexp.MustCompile(`^(GET|POST|PUT|DELETE|PATCH|HEAD|OPTIONS|TRACE|CONNECT)\s+(\S+)(?:\s+HTTP/[\d.]+)?$`)
+	httpHeaderLine          = regexp.MustCompile(`^([\w-]+)\s*:\s*(.*)$`)
+)
+
+// httpFileRequest is one ### block while it's being parsed
+type httpFileRequest struct {
+	title    string
+	name     string
+	method   string
+	target   string
+	headers  [][2]string
+	body     []string
+	inBody   bool
+	scripted bool
+}
+
+// ImportHTTPFile creates a preset per request in a .http file
+// @var declarations become hard variable values, {{var}} placeholders become {@var}
+func ImportHTTPFile(path string, opts HTTPImportOptions) (*ImportResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	result := &ImportResult{}
+	variables := make(map[string]string)
+	var parsed []*httpFileRequest
+	current := &httpFileRequest{}
+	inScript := false
+
+	for _, rawLine := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		line := strings.TrimSpace(rawLine)
+
+		if strings.HasPrefix(line, "###") {
+			parsed = append(parsed, current)
+			current = &httpFileRequest{title: strings.TrimSpace(strings.TrimPrefix(line, "###"))}
+			inScript = false
+			continue
+		}
+
+		// Response handler scripts: > {% ... %} possibly spanning several lines
+		if inScript || strings.HasPrefix(line, "> {%") {
+			current.scripted = true
+			inScript = !strings.HasSuffix(line, "%}")
+			continue
+		}
+		if strings.HasPrefix(line, ">") || strings.HasPrefix(line, "<>") {
+			current.scripted = true
+			continue
+		}
+
+		if current.inBody {
+			current.body = append(current.body, rawLine)
+			continue
+		}
+
+		switch {
+		case line == "":
+			if current.method != "" {
+				current.inBody = true
+			}
+		case httpNameDirective.MatchString(line):
+			current.name = strings.TrimSpace(httpNameDirective.FindStringSubmatch(line)[1])
+		case strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//"):
+			// Comment
+		case current.method == "" && httpVariableDeclaration.MatchString(line):
+			match := httpVariableDeclaration.FindStringSubmatch(line)
+			variables[match[1]] = strings.TrimSpace(match[2])
+		case current.method == "":
+			if match := httpRequestLine.FindStringSubmatch(line); match != nil {
+				current.method, current.target = match[1], match[2]
+			} else if !strings.Contains(line, " ") {
+				// A bare URL is a GET request
+				current.method, current.target = "GET", line
+			}
+		case strings.HasPrefix(line, "?") || strings.HasPrefix(line, "&"):
+			// Query string continued on the next lines
+			current.target += line
+		default:
+			if match := httpHeaderLine.FindStringSubmatch(line); match != nil {
+				current.headers = append(current.headers, [2]string{match[1], match[2]})
+			}
+		}
+	}
+	parsed = append(parsed, current)
+
+	// Declarations may reference each other: @url = {{host}}/v1
+	resolved := make(map[string]string)
+	for name, value := range variables {
+		for i := 0; i < 5 && postmanVariablePattern.MatchString(value); i++ {
+			value = postmanVariablePattern.ReplaceAllStringFunc(value, func(match string) string {
+				if inner, ok := variables[postmanVariablePattern.FindStringSubmatch(match)[1]]; ok {
+					return inner
+				}
+				return match
+			})
+		}
+		resolved[saulVariableName(name)] = value
+	}
+
+	var requests []ImportedRequest
+	for _, request := range parsed {
+		if request.method == "" {
+			continue
+		}
+		requests = append(requests, convertHTTPFileRequest(request, resolved, result))
+	}
+
+	err = WriteImportedRequests(prefix, requests, result)
+	return result, err
+}
+
+// convertHTTPFileRequest translates one parsed block into the shared preset request model
+func convertHTTPFileRequest(parsed *httpFileRequest, variables map[string]string, result *ImportResult) ImportedRequest {
+	name := parsed.name
+	if name == "" {
+		name = parsed.title
+	}
+	if name == "" {
+		base, _, _ := strings.Cut(parsed.target, "?")
+		if u, err := url.Parse(base); err == nil && u.Path != "" {
+			base = u.Path
+		}
+		name = parsed.method + " " + base
+	}
+	imported := ImportedRequest{Name: name}
+	label := imported.Label()
+
+	translate := func(text string) string {
+		return translatePlaceholders(label, text, postmanVariablePattern, result)
+	}
+
+	request := PresetRequest{
+		Method:    parsed.method,
+		Headers:   make(map[string]string),
+		Variables: variables,
+	}
+	request.URL, request.Query = splitRawURL(translate(parsed.target))
+
+	contentType := ""
+	for _, header := range parsed.headers {
+		if strings.EqualFold(header[0], "Content-Type") {
+			contentType = strings.ToLower(header[1])
+		}
+		if !IsTransportHeader(header[0]) {
+			request.Headers[header[0]] = translate(header[1])
+		}
+	}
+
+	body := strings.TrimSpace(strings.Join(parsed.body, "\n"))
+	switch {
+	case body == "":
+	case strings.HasPrefix(body, "<"):
+		result.Warn(label, "body loaded from a file ("+strings.SplitN(body, "\n", 2)[0]+") not imported")
+	case strings.Contains(contentType, "x-www-form-urlencoded"):
+		var fields []FormField
+		for _, pair := range strings.Split(strings.ReplaceAll(body, "\n", ""), "&") {
+			key, value, _ := strings.Cut(pair, "=")
+			fields = append(fields, FormField{Name: unescapeQuery(key), Value: translate(unescapeQuery(value))})
+		}
+		request.Body = formFieldsToJSON(label, "urlencoded", fields, result)
+	default:
+		body = translate(body)
+		if IsJSONObject([]byte(body)) {
+			request.Body = body
+		} else {
+			result.Warn(label, "body is not a JSON object (or has unquoted placeholders) - not converted")
+		}
+	}
+
+	if strings.Contains(parsed.target+strings.Join(parsed.body, "\n"), ".response.") {
+		result.Warn(label, "references another request's response - saul turns it into a plain variable")
+	}
+	if parsed.scripted {
+		result.Warn(label, "response handler script not imported")
+	}
+
+	imported.Request = request
+	return imported
+}
@@ -0,0 +1,185 @@
+package workspace
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ImportedRequest is the intermediate model every importer (curl, HAR, Postman,
+// Insomnia, Bruno...) produces: a request plus where it lived in the source collection
+type ImportedRequest struct {
+	Folders []string // Folder hierarchy in the source collection, outermost first
+	Name    string   // Request name, or "METHOD /path" when the source has none
+	Request PresetRequest
+}
+
+// ImportResult collects what an importer created and what it had to leave behind
+type ImportResult struct {
+	Presets  []string
+	Warnings []string
+}
+
+// Warn adds an untranslated item to the import report
+func (r *ImportResult) Warn(label, message string) {
+	r.Warnings = append(r.Warnings, label+": "+message)
+}
+
+// PresetName derives "<prefix>-<folder>-<name>" with every part slugified
+func (r ImportedRequest) PresetName(prefix string) string {
+	var parts []string
+	if prefix != "" {
+		parts = append(parts, slugify(prefix))
+	}
+	for _, folder := range r.Folders {
+		parts = append(parts, slugify(folder))
+	}
+	parts = append(parts, slugify(r.Name))
+	return strings.Join(parts, "-")
+}
+
+// CollectionPresetName derives "<prefix>/<folder>/<name>": the prefix and every folder
+// of the source become saul collections
+func (r ImportedRequest) CollectionPresetName(prefix string) string {
+	return collectionPath(prefix, append(append([]string{}, r.Folders...), r.Name)...)
+}
+
+// collectionPath slugifies each segment and joins them under the prefix collection
+func collectionPath(prefix string, segments ...string) string {
+	var parts []string
+	for _, segment := range strings.Split(NormalizePresetName(prefix), "/") {
+		if segment != "" {
+			parts = append(parts, slugify(segment))
+		}
+	}
+	for _, segment := range segments {
+		parts = append(parts, slugify(segment))
+	}
+	return strings.Join(parts, "/")
+}
+
+// Label is a human readable location used in import warnings
+func (r ImportedRequest) Label() string {
+	return strings.Join(append(append([]string{}, r.Folders...), r.Name), "/")
+}
+
+// WriteImportedRequest writes one imported request as a new preset and returns its name
+func WriteImportedRequest(prefix string, imported ImportedRequest) (string, error) {
+	preset := UniquePresetName(imported.PresetName(prefix))
+	if err := WritePresetRequest(preset, imported.Request); err != nil {
+		return "", fmt.Errorf("failed to write preset '%s': %v", preset, err)
+	}
+	return preset, nil
+}
+
+// WriteCollectionRequests writes a collection with folders (Postman, Insomnia, Bruno),
+// each folder becoming a saul collection under the prefix
+func WriteCollectionRequests(prefix string, requests []ImportedRequest, result *ImportResult) error {
+	// A request named like a folder next to it would turn into that collection
+	folders := make(map[string]bool)
+	for _, imported := range requests {
+		for i := range imported.Folders {
+			folders[collectionPath(prefix, imported.Folders[:i+1]...)] = true
+		}
+	}
+
+	for _, imported := range requests {
+		name := imported.CollectionPresetName(prefix)
+		candidate := name
+		for i := 2; folders[candidate] || PresetExists(candidate); i++ {
+			candidate = fmt.Sprintf("%s-%d", name, i)
+		}
+		if err := WritePresetRequest(candidate, imported.Request); err != nil {
+			return fmt.Errorf("failed to write preset '%s': %v", candidate, err)
+		}
+		result.Presets = append(result.Presets, candidate)
+	}
+	return nil
+}
+
+// WriteImportedRequests writes a whole collection, recording created presets in result
+func WriteImportedRequests(prefix string, requests []ImportedRequest, result *ImportResult) error {
+	for _, imported := range requests {
+		preset, err := WriteImportedRequest(prefix, imported)
+		if err != nil {
+			return err
+		}
+		result.Presets = append(result.Presets, preset)
+	}
+	return nil
+}
+
+// FormField is a urlencoded or multipart body field from a collection
+type FormField struct {
+	Name   string
+	Value  string
+	IsFile bool
+}
+
+// formFieldsToJSON converts form bodies into JSON fields for body.toml
+// saul sends bodies as JSON, so the conversion is reported as a warning
+func formFieldsToJSON(label, mode string, fields []FormField, result *ImportResult) string {
+	converted := make(map[string]string)
+	for _, field := range fields {
+		if field.IsFile {
+			result.Warn(label, fmt.Sprintf("form-data file field '%s' skipped", field.Name))
+			continue
+		}
+		converted[field.Name] = field.Value
+	}
+	if len(converted) == 0 {
+		return ""
+	}
+	result.Warn(label, mode+" body converted to JSON fields - saul sends bodies as JSON")
+	data, _ := json.Marshal(converted)
+	return string(data)
+}
+
+// basicAuthorization builds a Basic Authorization header value
+// Credentials containing variables can't be encoded up front, so a {@basic_auth} variable stands in
+func basicAuthorization(label, username, password string, result *ImportResult) string {
+	if hardVariablePattern.MatchString(username + password) {
+		result.Warn(label, "basic auth uses variables - set {@basic_auth} to base64(user:password)")
+		return "Basic {@basic_auth}"
+	}
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+var nonWordChars = regexp.MustCompile(`\W+`)
+
+// saulVariableName makes a foreign variable name fit saul's {@name} syntax
+func saulVariableName(name string) string {
+	return strings.Trim(nonWordChars.ReplaceAllString(name, "_"), "_")
+}
+
+// translatePlaceholders rewrites {{var}}-style placeholders as {@var} hard variables
+// Names starting with $ are generated values in Postman/Bruno and become {?var} prompts
+func translatePlaceholders(label, text string, pattern *regexp.Regexp, result *ImportResult) string {
+	return pattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := pattern.FindStringSubmatch(match)[1]
+		if strings.HasPrefix(name, "$") {
+			result.Warn(label, fmt.Sprintf("dynamic variable %s turned into a prompt", match))
+			return "{?" + saulVariableName(name) + "}"
+		}
+		return "{@" + saulVariableName(name) + "}"
+	})
+}
+
+// splitRawURL splits a URL into its base and query params without requiring it to be valid
+// (collection URLs usually still contain placeholders at this point)
+func splitRawURL(rawURL string) (string, map[string]string) {
+	query := make(map[string]string)
+	baseURL, rawQuery, _ := strings.Cut(rawURL, "?")
+	if rawQuery == "" {
+		return baseURL, query
+	}
+	for _, pair := range strings.Split(rawQuery, "&") {
+		key, value, _ := strings.Cut(pair, "=")
+		if key != "" {
+			query[key] = unescapeQuery(value)
+		}
+	}
+	return baseURL, query
+}
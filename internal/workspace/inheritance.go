@@ -0,0 +1,254 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// A preset's files are resolved in layers, later ones winning with Merge's deep-merge:
+//  0. the [headers] table of the user's config.toml, for headers only
+//  1. the preset named by extends = "base-api" in request.toml, itself fully resolved
+//  2. the collections above the preset, outermost first (headers and variables only)
+//  3. the preset's own file
+
+// ExtendsKey is the request.toml key naming the preset to inherit from
+const ExtendsKey = "extends"
+
+// ConfigSource marks values that come from the user's config.toml
+const ConfigSource = "config"
+
+// collectionFiles are the files a collection passes down to its presets
+var collectionFiles = map[string]bool{"headers": true, "variables": true}
+
+// extendedFiles are the files a preset inherits from the one it extends, body stays its own
+var extendedFiles = map[string]bool{"request": true, "headers": true, "query": true, "variables": true}
+
+// presetLayer is one file taking part in the resolution, and who it belongs to
+type presetLayer struct {
+	Source  string // Preset name, collection name with a trailing slash, or ConfigSource
+	Path    string
+	Handler *TomlHandler // Set instead of Path for layers that aren't a file
+}
+
+// ReadExtends returns the preset a preset extends, without creating any file
+func ReadExtends(preset string) string {
+	handler := ReadPresetFile(preset, "request")
+	if handler == nil {
+		return ""
+	}
+	return NormalizePresetName(handler.GetAsString(ExtendsKey))
+}
+
+// ValidateExtends checks that a preset can extend parent: it exists and doesn't lead back
+func ValidateExtends(preset, parent string) error {
+	parent = NormalizePresetName(parent)
+	if err := ValidatePresetName(parent); err != nil {
+		return err
+	}
+	if !PresetExists(parent) {
+		return fmt.Errorf(display.ErrPresetNotFound, parent)
+	}
+	chain := []string{preset}
+	for name := parent; name != ""; name = ReadExtends(name) {
+		chain = append(chain, name)
+		if name == preset {
+			return fmt.Errorf(display.ErrExtendsCycle, preset, strings.Join(chain, " -> "))
+		}
+		if len(chain) > 32 {
+			break
+		}
+	}
+	return nil
+}
+
+// resolutionLayers lists the files that make up a preset's file, lowest priority first
+func resolutionLayers(preset, fileType string, chain []string) ([]presetLayer, error) {
+	for _, name := range chain {
+		if name == preset {
+			return nil, fmt.Errorf(display.ErrExtendsCycle, chain[0], strings.Join(append(chain, preset), " -> "))
+		}
+	}
+	var layers []presetLayer
+	if len(chain) == 0 && fileType == "headers" {
+		if defaults := userHeaders(); defaults != nil {
+			layers = append(layers, presetLayer{Source: ConfigSource, Handler: defaults})
+		}
+	}
+	chain = append(chain, preset)
+
+	if parent := ReadExtends(preset); parent != "" && extendedFiles[fileType] {
+		if !PresetExists(parent) {
+			return nil, fmt.Errorf(display.ErrPresetNotFound, parent)
+		}
+		parentLayers, err := resolutionLayers(parent, fileType, chain)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, parentLayers...)
+	}
+
+	if collectionFiles[fileType] {
+		for _, collection := range collectionAncestors(preset) {
+			collectionPath, err := GetPresetPath(collection)
+			if err != nil {
+				return nil, err
+			}
+			layers = append(layers, presetLayer{Source: collection + "/", Path: filepath.Join(collectionPath, fileType+".toml")})
+		}
+	}
+
+	presetPath, err := GetPresetPath(preset)
+	if err != nil {
+		return nil, err
+	}
+	return append(layers, presetLayer{Source: preset, Path: filepath.Join(presetPath, fileType+".toml")}), nil
+}
+
+// userHeaders turns the config.toml [headers] table into a layer, nil when there are none
+func userHeaders() *TomlHandler {
+	headers := config.Current().Headers
+	if len(headers) == 0 {
+		return nil
+	}
+	handler, err := NewTomlHandlerFromBytes(nil)
+	if err != nil {
+		return nil
+	}
+	for name, value := range headers {
+		handler.Set(name, value)
+	}
+	return handler
+}
+
+// loadLayer reads a layer's file, nil when the preset or collection doesn't have one
+func loadLayer(layer presetLayer) (*TomlHandler, error) {
+	if layer.Handler != nil {
+		return layer.Handler.Clone()
+	}
+	if _, err := os.Stat(layer.Path); err != nil {
+		return nil, nil
+	}
+	handler, err := NewTomlHandler(layer.Path)
+	if err != nil {
+		return nil, fmt.Errorf(display.ErrFileLoadFailed, layer.Path)
+	}
+	return handler, nil
+}
+
+// mergeLayers deep-merges the layer files that exist and records which layer set each key
+// Header names are case-insensitive, so a layer's Accept replaces an earlier accept
+func mergeLayers(layers []presetLayer, foldCase bool) (*TomlHandler, map[string]string, error) {
+	merged, err := NewTomlHandlerFromBytes(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	sources := make(map[string]string)
+	for _, layer := range layers {
+		handler, err := loadLayer(layer)
+		if err != nil {
+			return nil, nil, err
+		}
+		if handler == nil {
+			continue
+		}
+		// extends is about the preset that declares it, it isn't passed down
+		handler.Delete(ExtendsKey)
+		if foldCase {
+			for _, key := range handler.Keys() {
+				for _, existing := range merged.Keys() {
+					if existing != key && strings.EqualFold(existing, key) {
+						merged.Delete(existing)
+						delete(sources, existing)
+					}
+				}
+			}
+		}
+		if err := merged.Merge(handler); err != nil {
+			return nil, nil, err
+		}
+		for _, key := range handler.LeafKeys() {
+			sources[key] = layer.Source
+		}
+	}
+	return merged, sources, nil
+}
+
+// LoadInheritedFile merges everything a preset inherits for a file, without its own values
+// Missing files count as empty
+func LoadInheritedFile(preset, fileType string) (*TomlHandler, error) {
+	layers, err := resolutionLayers(preset, fileType, nil)
+	if err != nil {
+		return nil, err
+	}
+	merged, _, err := mergeLayers(layers[:len(layers)-1], fileType == "headers")
+	return merged, err
+}
+
+// LoadResolvedPresetFile loads a preset file on top of everything it inherits
+// The result is for reading only, saving goes through the preset's own file
+func LoadResolvedPresetFile(preset, fileType string) (*TomlHandler, error) {
+	merged, _, err := ResolvePresetFile(preset, fileType)
+	return merged, err
+}
+
+// ResolvePresetFile returns the effective file and, for every key, the preset or collection it came from
+func ResolvePresetFile(preset, fileType string) (*TomlHandler, map[string]string, error) {
+	layers, err := resolutionLayers(preset, fileType, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mergeLayers(layers, fileType == "headers")
+}
+
+// ExtendingPresets lists the presets extending name, or a preset inside it when it's a collection
+// Presets inside name themselves don't count, they go along with it
+func ExtendingPresets(name string) ([]string, error) {
+	presets, err := ListPresets()
+	if err != nil {
+		return nil, err
+	}
+	var dependents []string
+	for _, preset := range presets {
+		if presetWithin(preset, name) {
+			continue
+		}
+		if parent := ReadExtends(preset); parent != "" && presetWithin(parent, name) {
+			dependents = append(dependents, preset)
+		}
+	}
+	return dependents, nil
+}
+
+// presetWithin reports whether preset is root or a preset of the root collection
+func presetWithin(preset, root string) bool {
+	return preset == root || strings.HasPrefix(preset, root+"/")
+}
+
+// RenameExtendsReferences points every preset that extends oldName at newName
+// When oldName is a collection, presets extending anything inside it follow as well
+func RenameExtendsReferences(oldName, newName string) error {
+	presets, err := ListPresets()
+	if err != nil {
+		return err
+	}
+	for _, preset := range presets {
+		parent := ReadExtends(preset)
+		if parent == "" || !presetWithin(parent, oldName) {
+			continue
+		}
+		handler, err := LoadPresetFile(preset, "request")
+		if err != nil {
+			return err
+		}
+		handler.Set(ExtendsKey, newName+strings.TrimPrefix(parent, oldName))
+		if err := SavePresetFile(preset, "request", handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Insomnia export format v4 (JSON or YAML), only the resources saul can translate are modelled
+
+type insomniaExport struct {
+	ExportFormat int                `json:"__export_format"`
+	Resources    []insomniaResource `json:"resources"`
+}
+
+type insomniaResource struct {
+	ID       string `json:"_id"`
+	Type     string `json:"_type"` // workspace, request_group, request, environment
+	ParentID string `json:"parentId"`
+	Name     string `json:"name"`
+
+	// Requests
+	Method         string                 `json:"method"`
+	URL            string                 `json:"url"`
+	Body           insomniaBody           `json:"body"`
+	Parameters     []insomniaParam        `json:"parameters"`
+	Headers        []insomniaParam        `json:"headers"`
+	Authentication map[string]interface{} `json:"authentication"`
+
+	// Environments
+	Data map[string]interface{} `json:"data"`
+}
+
+type insomniaBody struct {
+	MimeType string          `json:"mimeType"`
+	Text     string          `json:"text"`
+	Params   []insomniaParam `json:"params"`
+}
+
+type insomniaParam struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Type     string `json:"type"` // "file" for multipart uploads
+	Disabled bool   `json:"disabled"`
+}
+
+// InsomniaImportOptions controls an Insomnia export import
+type InsomniaImportOptions struct {
+	Environment string // Sub-environment name layered over the base environment
+	Prefix      string // Preset name prefix, defaults to the workspace name
+}
+
+// {{ _.name }} in current Insomnia, {{ name }} in older exports
+var insomniaVariablePattern = regexp.MustCompile(`\{\{\s*(?:_\.)?([^{}\s]+?)\s*\}\}`)
+
+// {% response ... %} and other template tags that only Insomnia can evaluate
+var insomniaTagPattern = regexp.MustCompile(`\{%.*?%\}`)
+
+// ImportInsomniaExport creates one preset per request in an Insomnia v4 export (JSON or YAML)
+func ImportInsomniaExport(path string, opts InsomniaImportOptions) (*ImportResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	var export insomniaExport
+	if err := decodeJSONOrYAML(data, &export); err != nil {
+		return nil, fmt.Errorf("invalid Insomnia export: %v", err)
+	}
+	if export.ExportFormat != 0 && export.ExportFormat != 4 {
+		return nil, fmt.Errorf("unsupported Insomnia export format %d (expected 4)", export.ExportFormat)
+	}
+
+	byID := make(map[string]insomniaResource)
+	for _, resource := range export.Resources {
+		byID[resource.ID] = resource
+	}
+
+	variables, err := insomniaVariables(export.Resources, byID, opts.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	prefix := opts.Prefix
+	var requests []ImportedRequest
+
+	for _, resource := range export.Resources {
+		switch resource.Type {
+		case "workspace":
+			if prefix == "" {
+				prefix = resource.Name
+			}
+		case "request":
+			imported := ImportedRequest{
+				Folders: insomniaFolders(resource, byID),
+				Name:    resource.Name,
+			}
+			imported.Request = convertInsomniaRequest(imported.Label(), resource, variables, result)
+			requests = append(requests, imported)
+		}
+	}
+
+	err = WriteCollectionRequests(prefix, requests, result)
+	return result, err
+}
+
+// insomniaVariables merges the base environment with the selected sub-environment
+func insomniaVariables(resources []insomniaResource, byID map[string]insomniaResource, envName string) (map[string]string, error) {
+	variables := make(map[string]string)
+	foundSubEnvironment := envName == ""
+
+	// Base environments hang off the workspace, sub-environments off a base environment
+	for _, resource := range resources {
+		if resource.Type == "environment" && byID[resource.ParentID].Type == "workspace" {
+			flattenEnvironment("", resource.Data, variables)
+		}
+	}
+	for _, resource := range resources {
+		if resource.Type == "environment" && byID[resource.ParentID].Type == "environment" && resource.Name == envName {
+			flattenEnvironment("", resource.Data, variables)
+			foundSubEnvironment = true
+		}
+	}
+
+	if !foundSubEnvironment {
+		return nil, fmt.Errorf("environment '%s' not found in Insomnia export", envName)
+	}
+	return variables, nil
+}
+
+// flattenEnvironment turns nested environment data into api_host style variable names
+func flattenEnvironment(prefix string, data map[string]interface{}, variables map[string]string) {
+	for key, value := range data {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenEnvironment(name, nested, variables)
+			continue
+		}
+		variables[saulVariableName(name)] = fmt.Sprintf("%v", value)
+	}
+}
+
+// insomniaFolders walks up the request_group chain to build the folder hierarchy
+func insomniaFolders(resource insomniaResource, byID map[string]insomniaResource) []string {
+	var folders []string
+	parent, ok := byID[resource.ParentID]
+	for ok && parent.Type == "request_group" {
+		folders = append([]string{parent.Name}, folders...)
+		parent, ok = byID[parent.ParentID]
+	}
+	return folders
+}
+
+// convertInsomniaRequest translates one Insomnia request into the shared preset request model
+func convertInsomniaRequest(label string, resource insomniaResource, variables map[string]string, result *ImportResult) PresetRequest {
+	translate := func(text string) string {
+		if insomniaTagPattern.MatchString(text) {
+			result.Warn(label, "template tags like {% response %} can't run in saul - turned into a prompt")
+			text = insomniaTagPattern.ReplaceAllString(text, "{?}")
+		}
+		return translatePlaceholders(label, text, insomniaVariablePattern, result)
+	}
+
+	request := PresetRequest{
+		Method:    strings.ToUpper(resource.Method),
+		Headers:   make(map[string]string),
+		Variables: variables,
+	}
+	request.URL, request.Query = splitRawURL(translate(resource.URL))
+
+	for _, param := range resource.Parameters {
+		if !param.Disabled && param.Name != "" {
+			request.Query[translate(param.Name)] = translate(param.Value)
+		}
+	}
+	for _, header := range resource.Headers {
+		if !header.Disabled && header.Name != "" && !IsTransportHeader(header.Name) {
+			request.Headers[header.Name] = translate(header.Value)
+		}
+	}
+
+	applyInsomniaAuth(label, resource.Authentication, &request, translate, result)
+
+	mimeType := strings.ToLower(resource.Body.MimeType)
+	switch {
+	case mimeType == "" && resource.Body.Text == "":
+		// No body
+	case strings.Contains(mimeType, "json"):
+		body := translate(resource.Body.Text)
+		if IsJSONObject([]byte(body)) {
+			request.Body = body
+		} else if strings.TrimSpace(body) != "" {
+			result.Warn(label, "JSON body is not an object (or has unquoted placeholders) - not converted")
+		}
+	case mimeType == "application/x-www-form-urlencoded" || mimeType == "multipart/form-data":
+		var fields []FormField
+		for _, param := range resource.Body.Params {
+			if !param.Disabled {
+				fields = append(fields, FormField{Name: param.Name, Value: translate(param.Value), IsFile: param.Type == "file"})
+			}
+		}
+		request.Body = formFieldsToJSON(label, mimeType, fields, result)
+	default:
+		result.Warn(label, fmt.Sprintf("'%s' body not supported", resource.Body.MimeType))
+	}
+
+	return request
+}
+
+// applyInsomniaAuth maps bearer, basic and API key authentication onto headers or query params
+func applyInsomniaAuth(label string, auth map[string]interface{}, request *PresetRequest, translate func(string) string, result *ImportResult) {
+	if len(auth) == 0 {
+		return
+	}
+	field := func(name string) string {
+		if value, ok := auth[name]; ok && value != nil {
+			return translate(fmt.Sprintf("%v", value))
+		}
+		return ""
+	}
+	if disabled, _ := auth["disabled"].(bool); disabled {
+		return
+	}
+
+	switch authType := field("type"); authType {
+	case "", "none":
+		return
+	case "bearer":
+		prefix := field("prefix")
+		if prefix == "" {
+			prefix = "Bearer"
+		}
+		request.Headers["Authorization"] = prefix + " " + field("token")
+	case "basic":
+		request.Headers["Authorization"] = basicAuthorization(label, field("username"), field("password"), result)
+	case "apikey":
+		if field("addTo") == "queryParams" {
+			request.Query[field("key")] = field("value")
+		} else {
+			request.Headers[field("key")] = field("value")
+		}
+	default:
+		result.Warn(label, fmt.Sprintf("'%s' auth not supported", authType))
+	}
+}
+
+// decodeJSONOrYAML decodes JSON, falling back to YAML for exports saved as .yaml
+func decodeJSONOrYAML(data []byte, target interface{}) error {
+	if err := json.Unmarshal(data, target); err == nil {
+		return nil
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	// Round-trip through JSON so the json struct tags apply to YAML input too
+	jsonData, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, target)
+}
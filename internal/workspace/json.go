@@ -53,4 +53,12 @@ func LoadTomlAsJSON(filePath string) ([]byte, error) {
 		return nil, err
 	}
 	return handler.ToJSON()
-}
\ No newline at end of file
+}
+
+// IsJSONObject checks if data is a JSON object that a TOML file can represent
+// Top-level arrays are rejected on purpose: a TOML document is always a table,
+// so body.toml has no way to hold one and callers skip those bodies instead
+func IsJSONObject(data []byte) bool {
+	var obj map[string]interface{}
+	return json.Unmarshal(data, &obj) == nil
+}
@@ -0,0 +1,76 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MetaFileType is the preset file describing the preset itself rather than the request
+// It's never sent anywhere, it's there so the preset can be found and understood later
+const MetaFileType = "meta"
+
+// MetaKeys are the keys meta.toml holds
+var MetaKeys = []string{"description", "tags", "owner", "docs"}
+
+// PresetMeta is what meta.toml says about a preset
+type PresetMeta struct {
+	Description string
+	Tags        []string
+	Owner       string
+	Docs        string // Link to the endpoint's documentation
+}
+
+// LoadPresetMeta reads a preset's meta.toml without creating it, missing means empty
+func LoadPresetMeta(preset string) PresetMeta {
+	presetPath, err := GetPresetPath(preset)
+	if err != nil {
+		return PresetMeta{}
+	}
+	filePath := filepath.Join(presetPath, MetaFileType+".toml")
+	if _, err := os.Stat(filePath); err != nil {
+		return PresetMeta{}
+	}
+	handler, err := NewTomlHandler(filePath)
+	if err != nil {
+		return PresetMeta{}
+	}
+	return PresetMeta{
+		Description: handler.GetAsString("description"),
+		Tags:        stringList(handler.Get("tags")),
+		Owner:       handler.GetAsString("owner"),
+		Docs:        handler.GetAsString("docs"),
+	}
+}
+
+// SplitTags reads tags as typed on the command line: refund,billing or [refund, billing]
+func SplitTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(strings.Trim(value, "[]"), ",") {
+		if tag = strings.Trim(strings.TrimSpace(tag), `"'`); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// stringList reads a TOML value as a list of strings, a single string being a list of one
+func stringList(value interface{}) []string {
+	var list []string
+	switch typed := value.(type) {
+	case string:
+		if typed = strings.TrimSpace(typed); typed != "" {
+			list = append(list, typed)
+		}
+	case []interface{}:
+		for _, item := range typed {
+			if text := strings.TrimSpace(fmt.Sprint(item)); text != "" {
+				list = append(list, text)
+			}
+		}
+	case []string:
+		return typed
+	}
+	return list
+}
@@ -0,0 +1,459 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// OpenAPI 3.x and Swagger 2.0 documents are walked as generic JSON trees:
+// both versions share most of the shape and $ref can point anywhere in the document
+
+// OpenAPIImportOptions controls an OpenAPI/Swagger import
+type OpenAPIImportOptions struct {
+	Tag    string // Only import operations carrying this tag
+	Prefix string // Preset name prefix, defaults to the API title
+}
+
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// {param} in paths and server URLs
+var openAPIPathParam = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// maxSchemaDepth stops skeleton generation on deeply nested or recursive schemas
+const maxSchemaDepth = 8
+
+// openAPIDoc wraps a decoded spec with $ref resolution
+type openAPIDoc struct {
+	root    map[string]interface{}
+	swagger bool // Swagger 2.0 rather than OpenAPI 3.x
+}
+
+// ImportOpenAPISpec creates one preset per operation in an OpenAPI 3.x or Swagger 2.0 document
+func ImportOpenAPISpec(path string, opts OpenAPIImportOptions) (*ImportResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	var root map[string]interface{}
+	if err := decodeJSONOrYAML(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %v", err)
+	}
+	doc := &openAPIDoc{root: root}
+	if _, ok := root["swagger"]; ok {
+		doc.swagger = true
+	} else if _, ok := root["openapi"]; !ok {
+		return nil, fmt.Errorf("invalid OpenAPI document: no 'openapi' or 'swagger' version field")
+	}
+
+	result := &ImportResult{}
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = stringField(mapField(root, "info"), "title")
+	}
+
+	baseURL := doc.serverURL(result)
+	paths := mapField(root, "paths")
+	pathNames := make([]string, 0, len(paths))
+	for name := range paths {
+		pathNames = append(pathNames, name)
+	}
+	sort.Strings(pathNames)
+
+	var requests []ImportedRequest
+	var operationIDs []string
+	for _, pathName := range pathNames {
+		pathItem := doc.resolve(paths[pathName])
+		for _, method := range openAPIMethods {
+			operation := doc.resolve(pathItem[method])
+			if operation == nil {
+				continue
+			}
+			if opts.Tag != "" && !hasTag(operation, opts.Tag) {
+				continue
+			}
+
+			name := stringField(operation, "operationId")
+			if name == "" {
+				name = strings.ToUpper(method) + " " + pathName
+			}
+			imported := ImportedRequest{Name: name}
+			imported.Request = doc.convertOperation(imported.Label(), method, baseURL, pathName, pathItem, operation, result)
+			requests = append(requests, imported)
+			operationIDs = append(operationIDs, name)
+		}
+	}
+
+	if len(requests) == 0 && opts.Tag != "" {
+		return result, fmt.Errorf("no operations tagged '%s' in %s", opts.Tag, path)
+	}
+
+	if err := WriteImportedRequests(prefix, requests, result); err != nil {
+		return result, err
+	}
+
+	// Link every preset to its operation so calls can be validated against the spec
+	for i, preset := range result.Presets {
+		if err := LinkOpenAPIOperation(preset, path, operationIDs[i]); err != nil {
+			return result, fmt.Errorf("failed to link preset '%s' to %s: %v", preset, operationIDs[i], err)
+		}
+	}
+	return result, nil
+}
+
+// serverURL returns the first server (OpenAPI 3) or scheme+host+basePath (Swagger 2)
+func (d *openAPIDoc) serverURL(result *ImportResult) string {
+	if d.swagger {
+		host := stringField(d.root, "host")
+		if host == "" {
+			result.Warn("spec", "no host defined - preset URLs only contain the path")
+			return strings.TrimSuffix(stringField(d.root, "basePath"), "/")
+		}
+		scheme := "https"
+		if schemes, ok := d.root["schemes"].([]interface{}); ok && len(schemes) > 0 {
+			scheme = fmt.Sprintf("%v", schemes[0])
+		}
+		return scheme + "://" + host + strings.TrimSuffix(stringField(d.root, "basePath"), "/")
+	}
+
+	servers, _ := d.root["servers"].([]interface{})
+	if len(servers) == 0 {
+		result.Warn("spec", "no servers defined - preset URLs only contain the path")
+		return ""
+	}
+	server := d.resolve(servers[0])
+	serverURL := stringField(server, "url")
+
+	// Server variables are filled with their defaults
+	variables := mapField(server, "variables")
+	serverURL = openAPIPathParam.ReplaceAllStringFunc(serverURL, func(match string) string {
+		name := match[1 : len(match)-1]
+		if value := stringField(mapField(variables, name), "default"); value != "" {
+			return value
+		}
+		return "{?" + saulVariableName(name) + "}"
+	})
+	if len(servers) > 1 {
+		result.Warn("spec", fmt.Sprintf("%d servers defined - using %s", len(servers), serverURL))
+	}
+	return strings.TrimSuffix(serverURL, "/")
+}
+
+// convertOperation translates one operation into the shared preset request model
+func (d *openAPIDoc) convertOperation(label, method, baseURL, pathName string, pathItem, operation map[string]interface{}, result *ImportResult) PresetRequest {
+	request := PresetRequest{
+		Method:  strings.ToUpper(method),
+		Headers: make(map[string]string),
+		Query:   make(map[string]string),
+	}
+
+	// Path params become soft variables so every call prompts for them
+	request.URL = baseURL + openAPIPathParam.ReplaceAllStringFunc(pathName, func(match string) string {
+		return "{?" + saulVariableName(match[1:len(match)-1]) + "}"
+	})
+
+	for _, param := range d.parameters(pathItem, operation) {
+		name := stringField(param, "name")
+		required, _ := param["required"].(bool)
+		switch stringField(param, "in") {
+		case "query":
+			if required {
+				request.Query[name] = d.parameterValue(param)
+			}
+		case "header":
+			if required && !IsTransportHeader(name) {
+				request.Headers[name] = d.parameterValue(param)
+			}
+		case "body":
+			// Swagger 2.0 request body
+			request.Body = d.bodyFromSchema(label, param["schema"], result)
+		}
+	}
+
+	if requestBody := d.resolve(operation["requestBody"]); requestBody != nil {
+		request.Body = d.bodyFromContent(label, mapField(requestBody, "content"), result)
+	}
+
+	d.applySecurity(label, operation, &request, result)
+	return request
+}
+
+// parameters merges path-level and operation-level parameters, the operation wins
+func (d *openAPIDoc) parameters(pathItem, operation map[string]interface{}) []map[string]interface{} {
+	var params []map[string]interface{}
+	index := make(map[string]int)
+	for _, source := range []map[string]interface{}{pathItem, operation} {
+		list, _ := source["parameters"].([]interface{})
+		for _, raw := range list {
+			param := d.resolve(raw)
+			if param == nil {
+				continue
+			}
+			key := stringField(param, "in") + ":" + stringField(param, "name")
+			if i, exists := index[key]; exists {
+				params[i] = param
+				continue
+			}
+			index[key] = len(params)
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// parameterValue uses the parameter's example or default, otherwise a prompt
+func (d *openAPIDoc) parameterValue(param map[string]interface{}) string {
+	schema := d.resolve(param["schema"])
+	for _, candidate := range []interface{}{param["example"], schema["example"], param["default"], schema["default"]} {
+		if candidate != nil {
+			return fmt.Sprintf("%v", candidate)
+		}
+	}
+	return "{?" + saulVariableName(stringField(param, "name")) + "}"
+}
+
+// bodyFromContent picks the JSON media type of an OpenAPI 3 request body
+func (d *openAPIDoc) bodyFromContent(label string, content map[string]interface{}, result *ImportResult) string {
+	var mediaTypes []string
+	for mediaType := range content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+
+	for _, mediaType := range mediaTypes {
+		if !strings.Contains(mediaType, "json") {
+			continue
+		}
+		media := d.resolve(content[mediaType])
+		if example, ok := media["example"]; ok {
+			return d.bodyFromValue(label, example, result)
+		}
+		examples := mapField(media, "examples")
+		exampleNames := make([]string, 0, len(examples))
+		for name := range examples {
+			exampleNames = append(exampleNames, name)
+		}
+		sort.Strings(exampleNames)
+		for _, name := range exampleNames {
+			if example, ok := d.resolve(examples[name])["value"]; ok {
+				return d.bodyFromValue(label, example, result)
+			}
+		}
+		return d.bodyFromSchema(label, media["schema"], result)
+	}
+
+	if len(mediaTypes) > 0 {
+		result.Warn(label, fmt.Sprintf("'%s' body not supported", mediaTypes[0]))
+	}
+	return ""
+}
+
+// bodyFromSchema uses the schema's example or builds a skeleton from its properties
+func (d *openAPIDoc) bodyFromSchema(label string, node interface{}, result *ImportResult) string {
+	schema := d.resolve(node)
+	if schema == nil {
+		return ""
+	}
+	if example, ok := schema["example"]; ok {
+		return d.bodyFromValue(label, example, result)
+	}
+	// Unresolved so a top-level $ref counts towards recursion detection
+	return d.bodyFromValue(label, d.skeleton(asMap(node), 0, make(map[string]bool)), result)
+}
+
+// bodyFromValue keeps JSON object bodies, body.toml can't hold anything else
+func (d *openAPIDoc) bodyFromValue(label string, value interface{}, result *ImportResult) string {
+	if _, ok := value.(map[string]interface{}); !ok {
+		result.Warn(label, "request body is not a JSON object - not converted")
+		return ""
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// skeleton builds a sample value from a schema: examples, defaults and enums first,
+// otherwise zero values of the declared type
+func (d *openAPIDoc) skeleton(schema map[string]interface{}, depth int, visiting map[string]bool) interface{} {
+	if ref := stringField(schema, "$ref"); ref != "" {
+		if visiting[ref] || depth > maxSchemaDepth {
+			return nil
+		}
+		visiting[ref] = true
+		defer delete(visiting, ref)
+		return d.skeleton(d.resolve(schema), depth+1, visiting)
+	}
+	if schema == nil || depth > maxSchemaDepth {
+		return nil
+	}
+
+	for _, key := range []string{"example", "default"} {
+		if value, ok := schema[key]; ok {
+			return value
+		}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[0]
+	}
+
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		merged := make(map[string]interface{})
+		for _, part := range allOf {
+			if object, ok := d.skeleton(asMap(part), depth+1, visiting).(map[string]interface{}); ok {
+				for key, value := range object {
+					merged[key] = value
+				}
+			}
+		}
+		return merged
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if options, ok := schema[key].([]interface{}); ok && len(options) > 0 {
+			return d.skeleton(asMap(options[0]), depth+1, visiting)
+		}
+	}
+
+	schemaType := stringField(schema, "type")
+	if schemaType == "" && schema["properties"] != nil {
+		schemaType = "object"
+	}
+	switch schemaType {
+	case "object":
+		object := make(map[string]interface{})
+		for name, property := range mapField(schema, "properties") {
+			if readOnly, _ := d.resolve(property)["readOnly"].(bool); readOnly {
+				continue
+			}
+			// Recursive references come back nil and are left out
+			if value := d.skeleton(asMap(property), depth+1, visiting); value != nil {
+				object[name] = value
+			}
+		}
+		return object
+	case "array":
+		item := d.skeleton(asMap(schema["items"]), depth+1, visiting)
+		if item == nil {
+			return []interface{}{}
+		}
+		return []interface{}{item}
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	}
+	return ""
+}
+
+// applySecurity maps the first security requirement onto headers or query params with {@} variables
+func (d *openAPIDoc) applySecurity(label string, operation map[string]interface{}, request *PresetRequest, result *ImportResult) {
+	requirements, ok := operation["security"].([]interface{})
+	if !ok {
+		requirements, _ = d.root["security"].([]interface{})
+	}
+	if len(requirements) == 0 {
+		return
+	}
+
+	schemes := mapField(mapField(d.root, "components"), "securitySchemes")
+	if d.swagger {
+		schemes = mapField(d.root, "securityDefinitions")
+	}
+
+	names := make([]string, 0)
+	for name := range asMap(requirements[0]) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		scheme := d.resolve(schemes[name])
+		if scheme == nil {
+			result.Warn(label, fmt.Sprintf("security scheme '%s' not defined", name))
+			continue
+		}
+		switch schemeType := stringField(scheme, "type"); schemeType {
+		case "http":
+			switch strings.ToLower(stringField(scheme, "scheme")) {
+			case "bearer":
+				request.Headers["Authorization"] = "Bearer {@token}"
+			case "basic":
+				request.Headers["Authorization"] = "Basic {@basic_auth}"
+				result.Warn(label, "basic auth - set {@basic_auth} to base64(user:password)")
+			default:
+				result.Warn(label, fmt.Sprintf("'%s' http auth not supported", stringField(scheme, "scheme")))
+			}
+		case "basic":
+			// Swagger 2.0
+			request.Headers["Authorization"] = "Basic {@basic_auth}"
+			result.Warn(label, "basic auth - set {@basic_auth} to base64(user:password)")
+		case "apiKey":
+			keyName := stringField(scheme, "name")
+			variable := "{@" + saulVariableName(keyName) + "}"
+			switch stringField(scheme, "in") {
+			case "query":
+				request.Query[keyName] = variable
+			case "cookie":
+				request.Headers["Cookie"] = keyName + "=" + variable
+			default:
+				request.Headers[keyName] = variable
+			}
+		case "oauth2", "openIdConnect":
+			request.Headers["Authorization"] = "Bearer {@token}"
+			result.Warn(label, schemeType+" flow not run by saul - paste an access token into {@token}")
+		default:
+			result.Warn(label, fmt.Sprintf("'%s' security scheme not supported", schemeType))
+		}
+	}
+}
+
+// resolve follows local $ref pointers ("#/components/schemas/User") and returns the target object
+func (d *openAPIDoc) resolve(node interface{}) map[string]interface{} {
+	object := asMap(node)
+	for hops := 0; object != nil && hops < 32; hops++ {
+		ref := stringField(object, "$ref")
+		if ref == "" {
+			return object
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			return nil // External references aren't followed
+		}
+		var target interface{} = d.root
+		for _, part := range strings.Split(ref[2:], "/") {
+			part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+			target = asMap(target)[part]
+		}
+		object = asMap(target)
+	}
+	return object
+}
+
+// hasTag reports whether an operation carries a tag (case-insensitive)
+func hasTag(operation map[string]interface{}, tag string) bool {
+	tags, _ := operation["tags"].([]interface{})
+	for _, candidate := range tags {
+		if strings.EqualFold(fmt.Sprintf("%v", candidate), tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func asMap(node interface{}) map[string]interface{} {
+	object, _ := node.(map[string]interface{})
+	return object
+}
+
+func mapField(object map[string]interface{}, key string) map[string]interface{} {
+	return asMap(object[key])
+}
+
+func stringField(object map[string]interface{}, key string) string {
+	value, _ := object[key].(string)
+	return value
+}
@@ -0,0 +1,436 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Keys in request.toml that link a preset to an OpenAPI operation
+const (
+	OpenAPISpecKey      = "openapi"
+	OpenAPIOperationKey = "operation"
+)
+
+// Values that are still unresolved {@var}/{?var} placeholders match any schema,
+// so a preset can be linted without prompting for variables
+var placeholderValue = regexp.MustCompile(`^\{[@?]\w*\}$`)
+
+// OpenAPIOperation is the operation a preset is linked to, used to validate traffic
+type OpenAPIOperation struct {
+	doc       *openAPIDoc
+	pathItem  map[string]interface{}
+	operation map[string]interface{}
+	Spec      string
+	ID        string
+}
+
+// LoadPresetOperation returns the OpenAPI operation linked in request.toml, or nil when none is set
+func LoadPresetOperation(preset string) (*OpenAPIOperation, error) {
+	requestHandler, err := LoadPresetFile(preset, "request")
+	if err != nil {
+		return nil, nil
+	}
+	spec := requestHandler.GetAsString(OpenAPISpecKey)
+	operationID := requestHandler.GetAsString(OpenAPIOperationKey)
+	if spec == "" || operationID == "" {
+		return nil, nil
+	}
+	return LoadOpenAPIOperation(spec, operationID)
+}
+
+// LoadOpenAPIOperation finds an operation by operationId or "METHOD /path"
+func LoadOpenAPIOperation(spec, operationID string) (*OpenAPIOperation, error) {
+	data, err := os.ReadFile(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI document %s: %v", spec, err)
+	}
+	var root map[string]interface{}
+	if err := decodeJSONOrYAML(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document %s: %v", spec, err)
+	}
+	doc := &openAPIDoc{root: root}
+	_, doc.swagger = root["swagger"]
+
+	wantMethod, wantPath, byPath := strings.Cut(operationID, " ")
+	for pathName, rawItem := range mapField(root, "paths") {
+		pathItem := doc.resolve(rawItem)
+		for _, method := range openAPIMethods {
+			operation := doc.resolve(pathItem[method])
+			if operation == nil {
+				continue
+			}
+			matches := stringField(operation, "operationId") == operationID
+			if byPath {
+				matches = strings.EqualFold(method, wantMethod) && pathName == wantPath
+			}
+			if matches {
+				return &OpenAPIOperation{doc: doc, pathItem: pathItem, operation: operation, Spec: spec, ID: operationID}, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("operation '%s' not found in %s", operationID, spec)
+}
+
+// LinkOpenAPIOperation records the spec and operation a preset was generated from
+func LinkOpenAPIOperation(preset, spec, operationID string) error {
+	if absolute, err := filepath.Abs(spec); err == nil {
+		spec = absolute
+	}
+	requestHandler, err := LoadPresetFile(preset, "request")
+	if err != nil {
+		return err
+	}
+	requestHandler.Set(OpenAPISpecKey, spec)
+	requestHandler.Set(OpenAPIOperationKey, operationID)
+	return SavePresetFile(preset, "request", requestHandler)
+}
+
+// ValidateRequest checks outgoing headers, query params and JSON body against the operation
+// Violations are returned as "query.limit: ..." style messages
+func (o *OpenAPIOperation) ValidateRequest(headers, query map[string]string, body []byte) []string {
+	var violations []string
+
+	for _, param := range o.doc.parameters(o.pathItem, o.operation) {
+		name := stringField(param, "name")
+		required, _ := param["required"].(bool)
+		schema := o.doc.resolve(param["schema"])
+		if schema == nil {
+			schema = param // Swagger 2.0 keeps type/enum on the parameter itself
+		}
+
+		var values map[string]string
+		switch stringField(param, "in") {
+		case "query":
+			values = query
+		case "header":
+			values = canonicalHeaders(headers)
+			name = strings.ToLower(name)
+		case "body":
+			violations = append(violations, o.validateBody("body", param["schema"], body, true)...)
+			continue
+		default:
+			continue // Path params are part of the URL template
+		}
+
+		value, present := values[name]
+		where := stringField(param, "in") + "." + stringField(param, "name")
+		if !present {
+			if required {
+				violations = append(violations, where+": required parameter missing")
+			}
+			continue
+		}
+		violations = append(violations, o.doc.validateValue(schema, parseParameter(value, schema), where, 0)...)
+	}
+
+	if requestBody := o.doc.resolve(o.operation["requestBody"]); requestBody != nil {
+		required, _ := requestBody["required"].(bool)
+		if schema := jsonSchemaFor(o.doc, mapField(requestBody, "content")); schema != nil {
+			violations = append(violations, o.validateBody("body", schema, body, required)...)
+		}
+	}
+
+	return violations
+}
+
+// ValidateResponse checks a received JSON body against the schema documented for its status
+func (o *OpenAPIOperation) ValidateResponse(status int, body []byte) []string {
+	responses := mapField(o.operation, "responses")
+	code := strconv.Itoa(status)
+
+	// Exact code first, then 2XX-style ranges, then default
+	var response map[string]interface{}
+	for _, key := range []string{code, code[:1] + "XX", code[:1] + "xx", "default"} {
+		if candidate, ok := responses[key]; ok {
+			response = o.doc.resolve(candidate)
+			break
+		}
+	}
+	if response == nil {
+		return []string{fmt.Sprintf("response: status %d is not documented", status)}
+	}
+
+	schema := response["schema"] // Swagger 2.0
+	if !o.doc.swagger {
+		schema = jsonSchemaFor(o.doc, mapField(response, "content"))
+	}
+	if schema == nil {
+		return nil
+	}
+	return o.validateBody("response.body", schema, body, false)
+}
+
+// validateBody parses a JSON body and validates it against a schema
+func (o *OpenAPIOperation) validateBody(where string, schema interface{}, body []byte, required bool) []string {
+	if len(strings.TrimSpace(string(body))) == 0 {
+		if required {
+			return []string{where + ": required body missing"}
+		}
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []string{where + ": not valid JSON"}
+	}
+	return o.doc.validateValue(asMap(schema), value, where, 0)
+}
+
+// jsonSchemaFor returns the schema of the first JSON media type in a content map
+func jsonSchemaFor(doc *openAPIDoc, content map[string]interface{}) interface{} {
+	var mediaTypes []string
+	for mediaType := range content {
+		if strings.Contains(mediaType, "json") {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	if len(mediaTypes) == 0 {
+		return nil
+	}
+	sort.Strings(mediaTypes)
+	return doc.resolve(content[mediaTypes[0]])["schema"]
+}
+
+// validateValue checks a decoded JSON value against a schema, returning path-level violations
+func (d *openAPIDoc) validateValue(schema map[string]interface{}, value interface{}, where string, depth int) []string {
+	schema = d.resolve(schema)
+	if schema == nil || depth > 32 {
+		return nil
+	}
+	if text, ok := value.(string); ok && placeholderValue.MatchString(text) {
+		return nil
+	}
+
+	var violations []string
+	fail := func(format string, args ...interface{}) {
+		violations = append(violations, where+": "+fmt.Sprintf(format, args...))
+	}
+
+	if value == nil {
+		if nullable, _ := schema["nullable"].(bool); nullable || schemaAllowsType(schema, "null") || len(schemaTypes(schema)) == 0 {
+			return nil
+		}
+		fail("null is not allowed")
+		return violations
+	}
+
+	for _, part := range asList(schema["allOf"]) {
+		violations = append(violations, d.validateValue(asMap(part), value, where, depth+1)...)
+	}
+	for _, key := range []string{"anyOf", "oneOf"} {
+		options := asList(schema[key])
+		if len(options) == 0 {
+			continue
+		}
+		matched := 0
+		for _, option := range options {
+			if len(d.validateValue(asMap(option), value, where, depth+1)) == 0 {
+				matched++
+			}
+		}
+		if matched == 0 {
+			fail("matches none of the %s alternatives", key)
+		} else if key == "oneOf" && matched > 1 {
+			fail("matches %d oneOf alternatives, expected exactly one", matched)
+		}
+	}
+
+	if enum := asList(schema["enum"]); len(enum) > 0 && !containsValue(enum, value) {
+		fail("%s is not one of %s", describeValue(value), describeValue(enum))
+	}
+
+	types := schemaTypes(schema)
+	if len(types) > 0 && !schemaAllowsType(schema, jsonType(value)) {
+		// Integers are valid numbers
+		if !(jsonType(value) == "integer" && schemaAllowsType(schema, "number")) {
+			fail("expected %s, got %s", strings.Join(types, " or "), jsonType(value))
+			return violations
+		}
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		properties := mapField(schema, "properties")
+		for _, name := range asList(schema["required"]) {
+			if _, present := typed[fmt.Sprintf("%v", name)]; !present {
+				violations = append(violations, fmt.Sprintf("%s.%v: required property missing", where, name))
+			}
+		}
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if property, ok := properties[key]; ok {
+				violations = append(violations, d.validateValue(asMap(property), typed[key], where+"."+key, depth+1)...)
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					violations = append(violations, where+"."+key+": property not allowed")
+				}
+			case map[string]interface{}:
+				violations = append(violations, d.validateValue(additional, typed[key], where+"."+key, depth+1)...)
+			}
+		}
+
+	case []interface{}:
+		if minimum, ok := numberField(schema, "minItems"); ok && float64(len(typed)) < minimum {
+			fail("expected at least %v items, got %d", minimum, len(typed))
+		}
+		if maximum, ok := numberField(schema, "maxItems"); ok && float64(len(typed)) > maximum {
+			fail("expected at most %v items, got %d", maximum, len(typed))
+		}
+		items := asMap(schema["items"])
+		for i, item := range typed {
+			violations = append(violations, d.validateValue(items, item, fmt.Sprintf("%s[%d]", where, i), depth+1)...)
+		}
+
+	case string:
+		length := float64(len([]rune(typed)))
+		if minimum, ok := numberField(schema, "minLength"); ok && length < minimum {
+			fail("shorter than %v characters", minimum)
+		}
+		if maximum, ok := numberField(schema, "maxLength"); ok && length > maximum {
+			fail("longer than %v characters", maximum)
+		}
+		if pattern := stringField(schema, "pattern"); pattern != "" {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(typed) {
+				fail("%q does not match pattern %s", typed, pattern)
+			}
+		}
+
+	case float64:
+		if minimum, ok := numberField(schema, "minimum"); ok {
+			exclusive, _ := schema["exclusiveMinimum"].(bool)
+			if typed < minimum || (exclusive && typed == minimum) {
+				fail("%v is below the minimum %v", typed, minimum)
+			}
+		}
+		if minimum, ok := numberField(schema, "exclusiveMinimum"); ok && typed <= minimum {
+			fail("%v must be greater than %v", typed, minimum)
+		}
+		if maximum, ok := numberField(schema, "maximum"); ok {
+			exclusive, _ := schema["exclusiveMaximum"].(bool)
+			if typed > maximum || (exclusive && typed == maximum) {
+				fail("%v is above the maximum %v", typed, maximum)
+			}
+		}
+		if maximum, ok := numberField(schema, "exclusiveMaximum"); ok && typed >= maximum {
+			fail("%v must be less than %v", typed, maximum)
+		}
+	}
+
+	return violations
+}
+
+// schemaTypes returns the declared type(s), OpenAPI 3.1 allows a list
+func schemaTypes(schema map[string]interface{}) []string {
+	switch typed := schema["type"].(type) {
+	case string:
+		return []string{typed}
+	case []interface{}:
+		var types []string
+		for _, t := range typed {
+			types = append(types, fmt.Sprintf("%v", t))
+		}
+		return types
+	}
+	return nil
+}
+
+func schemaAllowsType(schema map[string]interface{}, valueType string) bool {
+	for _, t := range schemaTypes(schema) {
+		if t == valueType {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType names a decoded JSON value the way JSON Schema does
+func jsonType(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if typed == math.Trunc(typed) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "unknown"
+}
+
+// parseParameter converts a query/header string into the type its schema expects
+func parseParameter(value string, schema map[string]interface{}) interface{} {
+	switch {
+	case schemaAllowsType(schema, "integer"), schemaAllowsType(schema, "number"):
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	case schemaAllowsType(schema, "boolean"):
+		if boolean, err := strconv.ParseBool(value); err == nil {
+			return boolean
+		}
+	case schemaAllowsType(schema, "array"):
+		var items []interface{}
+		for _, item := range strings.Split(value, ",") {
+			items = append(items, parseParameter(item, asMap(schema["items"])))
+		}
+		return items
+	}
+	return value
+}
+
+// canonicalHeaders lower-cases header names, they're case-insensitive
+func canonicalHeaders(headers map[string]string) map[string]string {
+	canonical := make(map[string]string, len(headers))
+	for name, value := range headers {
+		canonical[strings.ToLower(name)] = value
+	}
+	return canonical
+}
+
+func containsValue(list []interface{}, value interface{}) bool {
+	for _, candidate := range list {
+		if fmt.Sprintf("%v", candidate) == fmt.Sprintf("%v", value) {
+			return true
+		}
+	}
+	return false
+}
+
+func describeValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+func numberField(object map[string]interface{}, key string) (float64, bool) {
+	number, ok := object[key].(float64)
+	return number, ok
+}
+
+func asList(node interface{}) []interface{} {
+	list, _ := node.([]interface{})
+	return list
+}
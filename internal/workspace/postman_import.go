@@ -0,0 +1,327 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Postman Collection v2.1 structure (https://schema.postman.com/collection/json/v2.1.0/draft-07/docs/index.html)
+// Only the fields saul can translate are modelled
+
+type postmanCollection struct {
+	Info struct {
+		Name string `json:"name"`
+	} `json:"info"`
+	Item     []postmanItem     `json:"item"`
+	Auth     *postmanAuth      `json:"auth"`
+	Event    []postmanEvent    `json:"event"`
+	Variable []postmanKeyValue `json:"variable"`
+}
+
+type postmanItem struct {
+	Name    string          `json:"name"`
+	Item    []postmanItem   `json:"item"` // Set for folders
+	Request *postmanRequest `json:"request"`
+	Auth    *postmanAuth    `json:"auth"` // Folder-level auth
+	Event   []postmanEvent  `json:"event"`
+}
+
+type postmanRequest struct {
+	Method string            `json:"method"`
+	Header []postmanKeyValue `json:"header"`
+	URL    postmanURL        `json:"url"`
+	Body   *postmanBody      `json:"body"`
+	Auth   *postmanAuth      `json:"auth"`
+}
+
+type postmanURL struct {
+	Raw   string            `json:"raw"`
+	Query []postmanKeyValue `json:"query"`
+}
+
+type postmanBody struct {
+	Mode       string            `json:"mode"`
+	Raw        string            `json:"raw"`
+	URLEncoded []postmanKeyValue `json:"urlencoded"`
+	FormData   []postmanKeyValue `json:"formdata"`
+}
+
+type postmanAuth struct {
+	Type   string            `json:"type"`
+	Bearer []postmanKeyValue `json:"bearer"`
+	Basic  []postmanKeyValue `json:"basic"`
+	APIKey []postmanKeyValue `json:"apikey"`
+}
+
+type postmanEvent struct {
+	Listen string `json:"listen"`
+}
+
+type postmanKeyValue struct {
+	Key      string      `json:"key"`
+	Value    interface{} `json:"value"`
+	Type     string      `json:"type"` // "file" for form-data uploads
+	Disabled bool        `json:"disabled"`
+	Enabled  *bool       `json:"enabled"` // Environments use enabled instead of disabled
+}
+
+type postmanEnvironment struct {
+	Values []postmanKeyValue `json:"values"`
+}
+
+// UnmarshalJSON accepts both the string and the object form of a request
+func (r *postmanRequest) UnmarshalJSON(data []byte) error {
+	var rawURL string
+	if err := json.Unmarshal(data, &rawURL); err == nil {
+		*r = postmanRequest{Method: "GET", URL: postmanURL{Raw: rawURL}}
+		return nil
+	}
+	type plain postmanRequest
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+// UnmarshalJSON accepts both the string and the object form of a URL
+func (u *postmanURL) UnmarshalJSON(data []byte) error {
+	var rawURL string
+	if err := json.Unmarshal(data, &rawURL); err == nil {
+		*u = postmanURL{Raw: rawURL}
+		return nil
+	}
+	type plain postmanURL
+	return json.Unmarshal(data, (*plain)(u))
+}
+
+// stringValue renders a Postman value (which may be a number or bool) as text
+func (kv postmanKeyValue) stringValue() string {
+	if kv.Value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", kv.Value)
+}
+
+// active reports whether a key/value entry is switched on
+func (kv postmanKeyValue) active() bool {
+	if kv.Enabled != nil {
+		return *kv.Enabled
+	}
+	return !kv.Disabled
+}
+
+// PostmanImportOptions controls a Postman collection import
+type PostmanImportOptions struct {
+	EnvironmentFile string // Optional Postman environment export with variable values
+	Prefix          string // Preset name prefix, defaults to the collection name
+}
+
+// postmanImporter carries state while walking a collection
+type postmanImporter struct {
+	variables map[string]string
+	requests  []ImportedRequest
+	result    *ImportResult
+}
+
+var postmanVariablePattern = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+
+// ImportPostmanCollection creates one preset per request in a Postman v2.1 collection
+// Folders become name prefixes, {{var}} placeholders become {@var} hard variables
+func ImportPostmanCollection(path string, opts PostmanImportOptions) (*ImportResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	var collection postmanCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, fmt.Errorf("invalid Postman collection: %v", err)
+	}
+
+	importer := &postmanImporter{
+		variables: make(map[string]string),
+		result:    &ImportResult{},
+	}
+
+	// Collection variables first, environment values override them
+	for _, variable := range collection.Variable {
+		if variable.active() {
+			importer.variables[saulVariableName(variable.Key)] = variable.stringValue()
+		}
+	}
+	if opts.EnvironmentFile != "" {
+		envData, err := os.ReadFile(opts.EnvironmentFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", opts.EnvironmentFile, err)
+		}
+		var env postmanEnvironment
+		if err := json.Unmarshal(envData, &env); err != nil {
+			return nil, fmt.Errorf("invalid Postman environment: %v", err)
+		}
+		for _, variable := range env.Values {
+			if variable.active() {
+				importer.variables[saulVariableName(variable.Key)] = variable.stringValue()
+			}
+		}
+	}
+
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = collection.Info.Name
+	}
+
+	importer.reportEvents(collection.Info.Name, collection.Event)
+	importer.walk(collection.Item, nil, collection.Auth)
+
+	err = WriteCollectionRequests(prefix, importer.requests, importer.result)
+	return importer.result, err
+}
+
+// walk collects every request in a list of items, recursing into folders
+func (p *postmanImporter) walk(items []postmanItem, folders []string, inheritedAuth *postmanAuth) {
+	for _, item := range items {
+		imported := ImportedRequest{Folders: folders, Name: item.Name}
+		p.reportEvents(imported.Label(), item.Event)
+
+		if item.Request == nil {
+			// Folder: its auth applies to everything underneath unless overridden
+			auth := inheritedAuth
+			if item.Auth != nil {
+				auth = item.Auth
+			}
+			p.walk(item.Item, append(append([]string{}, folders...), item.Name), auth)
+			continue
+		}
+
+		auth := inheritedAuth
+		if item.Request.Auth != nil {
+			auth = item.Request.Auth
+		}
+
+		imported.Request = p.convertRequest(imported.Label(), item.Request, auth)
+		p.requests = append(p.requests, imported)
+	}
+}
+
+// convertRequest translates one Postman request into the shared preset request model
+func (p *postmanImporter) convertRequest(label string, req *postmanRequest, auth *postmanAuth) PresetRequest {
+	request := PresetRequest{
+		Method:    strings.ToUpper(req.Method),
+		Headers:   make(map[string]string),
+		Variables: p.variables,
+	}
+
+	// URL: query comes from the structured list when present, otherwise from the raw URL
+	request.URL, request.Query = splitRawURL(p.translate(label, req.URL.Raw))
+	if len(req.URL.Query) > 0 {
+		request.Query = make(map[string]string)
+		for _, param := range req.URL.Query {
+			if param.active() {
+				request.Query[p.translate(label, param.Key)] = p.translate(label, param.stringValue())
+			}
+		}
+	}
+
+	for _, header := range req.Header {
+		if header.active() && !IsTransportHeader(header.Key) {
+			request.Headers[header.Key] = p.translate(label, header.stringValue())
+		}
+	}
+
+	p.applyAuth(label, auth, &request)
+
+	if req.Body != nil {
+		request.Body = p.convertBody(label, req.Body)
+	}
+
+	return request
+}
+
+// convertBody turns raw JSON, urlencoded and form-data bodies into JSON for body.toml
+func (p *postmanImporter) convertBody(label string, body *postmanBody) string {
+	switch body.Mode {
+	case "raw":
+		if strings.TrimSpace(body.Raw) == "" {
+			return ""
+		}
+		raw := p.translate(label, body.Raw)
+		if !IsJSONObject([]byte(raw)) {
+			p.result.Warn(label, "raw body is not a JSON object (unquoted {{var}} placeholders?) - not converted")
+			return ""
+		}
+		return raw
+
+	case "urlencoded", "formdata":
+		params := body.URLEncoded
+		if body.Mode == "formdata" {
+			params = body.FormData
+		}
+		var fields []FormField
+		for _, param := range params {
+			if param.active() {
+				fields = append(fields, FormField{
+					Name:   param.Key,
+					Value:  p.translate(label, param.stringValue()),
+					IsFile: param.Type == "file",
+				})
+			}
+		}
+		return formFieldsToJSON(label, body.Mode, fields, p.result)
+
+	default:
+		p.result.Warn(label, fmt.Sprintf("'%s' body not supported", body.Mode))
+		return ""
+	}
+}
+
+// applyAuth maps bearer, basic and API key auth onto headers or query params
+func (p *postmanImporter) applyAuth(label string, auth *postmanAuth, request *PresetRequest) {
+	if auth == nil || auth.Type == "" || auth.Type == "noauth" {
+		return
+	}
+
+	switch auth.Type {
+	case "bearer":
+		token := p.translate(label, postmanAuthValue(auth.Bearer, "token"))
+		request.Headers["Authorization"] = "Bearer " + token
+
+	case "basic":
+		username := p.translate(label, postmanAuthValue(auth.Basic, "username"))
+		password := p.translate(label, postmanAuthValue(auth.Basic, "password"))
+		request.Headers["Authorization"] = basicAuthorization(label, username, password, p.result)
+
+	case "apikey":
+		key := p.translate(label, postmanAuthValue(auth.APIKey, "key"))
+		value := p.translate(label, postmanAuthValue(auth.APIKey, "value"))
+		if postmanAuthValue(auth.APIKey, "in") == "query" {
+			request.Query[key] = value
+		} else {
+			request.Headers[key] = value
+		}
+
+	default:
+		p.result.Warn(label, fmt.Sprintf("'%s' auth not supported", auth.Type))
+	}
+}
+
+// postmanAuthValue finds a named parameter in a Postman auth block
+func postmanAuthValue(params []postmanKeyValue, key string) string {
+	for _, param := range params {
+		if param.Key == key {
+			return param.stringValue()
+		}
+	}
+	return ""
+}
+
+// translate rewrites {{var}} placeholders as saul variables
+func (p *postmanImporter) translate(label, text string) string {
+	return translatePlaceholders(label, text, postmanVariablePattern, p.result)
+}
+
+// reportEvents records pre-request and test scripts, which saul can't run
+func (p *postmanImporter) reportEvents(label string, events []postmanEvent) {
+	for _, event := range events {
+		p.result.Warn(label, event.Listen+" script not imported")
+	}
+}
@@ -0,0 +1,135 @@
+package workspace
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
+	"github.com/DeprecatedLuar/better-curl-saul/pkg/display"
+)
+
+// PresetCopyOptions controls which parts of a preset go along to the new name
+type PresetCopyOptions struct {
+	SkipHistory   bool // Leave .history behind
+	SkipVariables bool // Leave variables.toml behind, its values are often secrets
+}
+
+// skips reports whether a top-level entry of the preset directory stays behind
+func (o PresetCopyOptions) skips(name string) bool {
+	return (o.SkipHistory && name == ".history") || (o.SkipVariables && name == "variables.toml")
+}
+
+// CopyPreset duplicates a preset directory under a new name
+func CopyPreset(source, destination string, opts PresetCopyOptions) error {
+	sourcePath, destinationPath, err := presetPairPaths(source, destination)
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(sourcePath)
+	if err != nil {
+		return fmt.Errorf(display.ErrDirectoryFailed)
+	}
+	if err := os.MkdirAll(destinationPath, config.DirPermissions); err != nil {
+		return fmt.Errorf(display.ErrDirectoryFailed)
+	}
+	for _, entry := range entries {
+		if opts.skips(entry.Name()) {
+			continue
+		}
+		err := copyPath(filepath.Join(sourcePath, entry.Name()), filepath.Join(destinationPath, entry.Name()))
+		if err != nil {
+			// Don't leave half a preset around
+			os.RemoveAll(destinationPath)
+			return fmt.Errorf(display.ErrPresetCopyFailed, source, destination, err)
+		}
+	}
+	return nil
+}
+
+// MovePreset renames a preset directory, dropping the parts the options leave behind
+func MovePreset(source, destination string, opts PresetCopyOptions) error {
+	sourcePath, destinationPath, err := presetPairPaths(source, destination)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destinationPath), config.DirPermissions); err != nil {
+		return fmt.Errorf(display.ErrDirectoryFailed)
+	}
+	if err := os.Rename(sourcePath, destinationPath); err != nil {
+		return fmt.Errorf(display.ErrPresetCopyFailed, source, destination, err)
+	}
+	pruneEmptyCollections(source)
+	for _, name := range []string{".history", "variables.toml"} {
+		if opts.skips(name) {
+			os.RemoveAll(filepath.Join(destinationPath, name))
+		}
+	}
+	return nil
+}
+
+// presetPairPaths resolves both directories, the source must exist and the destination must not
+func presetPairPaths(source, destination string) (string, string, error) {
+	sourcePath, err := GetPresetPath(source)
+	if err != nil {
+		return "", "", err
+	}
+	destinationPath, err := GetPresetPath(destination)
+	if err != nil {
+		return "", "", err
+	}
+	if !PresetExists(source) {
+		return "", "", fmt.Errorf(display.ErrPresetNotFound, source)
+	}
+	if PresetExists(destination) {
+		return "", "", fmt.Errorf(display.ErrPresetAlreadyExists, destination)
+	}
+	return sourcePath, destinationPath, nil
+}
+
+// copyPath copies a file or a whole directory tree
+func copyPath(source, destination string) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return copyFile(source, destination, info.Mode().Perm())
+	}
+
+	if err := os.MkdirAll(destination, config.DirPermissions); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(source)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := copyPath(filepath.Join(source, entry.Name()), filepath.Join(destination, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFile copies one file's contents, keeping its permissions
+func copyFile(source, destination string, perm os.FileMode) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(destination, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,194 @@
+package workspace
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// DefaultImportHistoryCount is the history size enabled on presets that are created
+// together with a recorded response
+const DefaultImportHistoryCount = 5
+
+// PresetRequest is the format-neutral request shape importers convert into
+// before it gets written out as preset TOML files
+type PresetRequest struct {
+	Method  string
+	URL     string // Base URL without query string
+	Headers map[string]string
+	Query   map[string]string
+	Body    string // Raw JSON body (empty for no body)
+
+	// Values for {@name} hard variables used anywhere in the request, written to variables.toml
+	Variables map[string]string
+}
+
+// WritePresetRequest writes a request into a preset's request/headers/query/body files
+// Existing headers and query params are kept, body is replaced when one is given
+func WritePresetRequest(preset string, req PresetRequest) error {
+	// Ensure preset directory exists
+	err := CreatePresetDirectory(preset)
+	if err != nil && !os.IsExist(err) {
+		return fmt.Errorf("failed to create preset directory: %v", err)
+	}
+
+	// Convert body (JSON → TOML)
+	if req.Body != "" {
+		bodyHandler, err := NewTomlHandlerFromJSON([]byte(req.Body))
+		if err != nil {
+			return fmt.Errorf("invalid JSON body: %v", err)
+		}
+		err = SavePresetFile(preset, "body", bodyHandler)
+		if err != nil {
+			return fmt.Errorf("failed to save body: %v", err)
+		}
+	}
+
+	// Convert headers
+	if len(req.Headers) > 0 {
+		headersHandler, err := LoadPresetFile(preset, "headers")
+		if err != nil {
+			return fmt.Errorf("failed to load headers file: %v", err)
+		}
+		for key, val := range req.Headers {
+			headersHandler.Set(key, val)
+		}
+		err = SavePresetFile(preset, "headers", headersHandler)
+		if err != nil {
+			return fmt.Errorf("failed to save headers: %v", err)
+		}
+	}
+
+	// Convert query params
+	if len(req.Query) > 0 {
+		queryHandler, err := LoadPresetFile(preset, "query")
+		if err != nil {
+			return fmt.Errorf("failed to load query file: %v", err)
+		}
+		for key, val := range req.Query {
+			queryHandler.Set(key, val)
+		}
+		err = SavePresetFile(preset, "query", queryHandler)
+		if err != nil {
+			return fmt.Errorf("failed to save query: %v", err)
+		}
+	}
+
+	// Convert request (method, baseURL without query params)
+	method := req.Method
+	if method == "" {
+		method = "GET"
+	}
+	requestHandler, err := LoadPresetFile(preset, "request")
+	if err != nil {
+		return fmt.Errorf("failed to load request file: %v", err)
+	}
+	requestHandler.Set("method", method)
+	requestHandler.Set("url", req.URL)
+	err = SavePresetFile(preset, "request", requestHandler)
+	if err != nil {
+		return fmt.Errorf("failed to save request: %v", err)
+	}
+
+	if len(req.Variables) > 0 {
+		return seedHardVariables(preset, req)
+	}
+	return nil
+}
+
+var hardVariablePattern = regexp.MustCompile(`\{@(\w+)\}`)
+
+// seedHardVariables stores known values for the hard variables a request uses
+// Keys follow the "<target>.<name>" layout the variables package reads at call time
+func seedHardVariables(preset string, req PresetRequest) error {
+	texts := map[string][]string{
+		"request": {req.URL},
+		"body":    {req.Body},
+	}
+	for key, value := range req.Headers {
+		texts["headers"] = append(texts["headers"], key, value)
+	}
+	for key, value := range req.Query {
+		texts["query"] = append(texts["query"], key, value)
+	}
+
+	variablesHandler, err := LoadPresetFile(preset, "variables")
+	if err != nil {
+		return fmt.Errorf("failed to load variables file: %v", err)
+	}
+	for target, values := range texts {
+		for _, text := range values {
+			for _, match := range hardVariablePattern.FindAllStringSubmatch(text, -1) {
+				if value, known := req.Variables[match[1]]; known {
+					variablesHandler.Set(target+"."+match[1], value)
+				}
+			}
+		}
+	}
+	if err := SavePresetFile(preset, "variables", variablesHandler); err != nil {
+		return fmt.Errorf("failed to save variables: %v", err)
+	}
+	return nil
+}
+
+// EnableHistory sets history_count on a preset so recorded and future responses are kept
+func EnableHistory(preset string, count int) error {
+	requestHandler, err := LoadPresetFile(preset, "request")
+	if err != nil {
+		return err
+	}
+	if requestHandler.Get("history_count") == nil {
+		requestHandler.Set("history_count", int64(count))
+	}
+	return SavePresetFile(preset, "request", requestHandler)
+}
+
+var slugInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// PresetNameFor derives a preset name like "<prefix>-get-users-42" from a request
+func PresetNameFor(prefix, method, path string) string {
+	return ImportedRequest{Name: method + " " + path}.PresetName(prefix)
+}
+
+// slugify turns "GET /users/42" into "get-users-42"
+func slugify(s string) string {
+	slug := strings.Trim(slugInvalidChars.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if slug == "" {
+		return "root"
+	}
+	return slug
+}
+
+// unescapeQuery decodes a query value, keeping it as-is when it isn't valid escaping
+func unescapeQuery(value string) string {
+	if unescaped, err := url.QueryUnescape(value); err == nil {
+		return unescaped
+	}
+	return value
+}
+
+// UniquePresetName returns name, or name-2, name-3... if the preset already exists
+func UniquePresetName(name string) string {
+	candidate := name
+	for i := 2; PresetExists(candidate); i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	return candidate
+}
+
+// IsTransportHeader reports headers that describe the connection rather than the request,
+// these are never written into headers.toml
+func IsTransportHeader(name string) bool {
+	if strings.HasPrefix(name, ":") {
+		return true // HTTP/2 pseudo-headers (:authority, :path...)
+	}
+	switch strings.ToLower(name) {
+	case "host", "content-length", "accept-encoding", "connection", "proxy-connection",
+		"keep-alive", "proxy-authenticate", "proxy-authorization", "te", "trailer",
+		"transfer-encoding", "upgrade":
+		return true
+	}
+	return false
+}
@@ -16,12 +16,26 @@ func GetConfigDir() (string, error) {
 }
 
 // GetPresetPath returns the full path to a specific preset directory
+// Collection names map to nested directories: github/issues -> presets/github/issues
 func GetPresetPath(name string) (string, error) {
 	presetsDir, err := config.GetPresetsPath()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(presetsDir, name), nil
+	if err := ValidatePresetName(name); err != nil {
+		return "", err
+	}
+	return filepath.Join(presetsDir, filepath.FromSlash(name)), nil
+}
+
+// PresetExists checks if a preset directory exists on disk
+func PresetExists(name string) bool {
+	presetPath, err := GetPresetPath(name)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(presetPath)
+	return err == nil
 }
 
 // CreatePresetDirectory creates a new preset directory with default TOML files
@@ -31,19 +45,33 @@ func CreatePresetDirectory(name string) error {
 		return err
 	}
 
-	// Create preset directory
-	err = os.MkdirAll(presetPath, config.DirPermissions)
-	if err != nil {
+	// Other TOML files are created on-demand when data is actually added
+	return ensurePresetDirectory(name, presetPath)
+}
+
+// ensurePresetDirectory creates a preset directory, seeding brand-new presets
+// with the config.toml history default. Existing presets are left as they are
+func ensurePresetDirectory(name, presetPath string) error {
+	if _, err := os.Stat(presetPath); err == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(presetPath, config.DirPermissions); err != nil {
 		return fmt.Errorf(display.ErrDirectoryFailed)
 	}
 
-	// Don't create any TOML files initially
-	// Files will be created on-demand when data is actually added
+	return seedHistoryDefault(name)
+}
 
+// seedHistoryDefault writes the config.toml history default into a new or imported preset
+func seedHistoryDefault(name string) error {
+	if history := config.Current().History; history > 0 {
+		return EnableHistory(name, history)
+	}
 	return nil
 }
 
-// ListPresets returns a list of all preset names
+// ListPresets returns a list of all preset names, collections included as github/issues/create
 func ListPresets() ([]string, error) {
 	presetsDir, err := config.GetPresetsPath()
 	if err != nil {
@@ -56,19 +84,7 @@ func ListPresets() ([]string, error) {
 		return nil, fmt.Errorf(display.ErrDirectoryFailed)
 	}
 
-	entries, err := os.ReadDir(presetsDir)
-	if err != nil {
-		return nil, fmt.Errorf(display.ErrDirectoryFailed)
-	}
-
-	var presets []string
-	for _, entry := range entries {
-		if entry.IsDir() {
-			presets = append(presets, entry.Name())
-		}
-	}
-
-	return presets, nil
+	return ListCollection("")
 }
 
 // DeletePreset removes a preset directory and all its files
@@ -89,5 +105,6 @@ func DeletePreset(name string) error {
 		return fmt.Errorf(display.ErrDirectoryFailed)
 	}
 
+	pruneEmptyCollections(name)
 	return nil
 }
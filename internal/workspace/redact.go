@@ -0,0 +1,75 @@
+package workspace
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/DeprecatedLuar/better-curl-saul/internal/config"
+)
+
+// RedactedValue replaces secrets in anything saul exports for sharing
+const RedactedValue = "REDACTED"
+
+// sensitiveKeyPattern matches header, query and JSON field names that usually carry secrets
+var sensitiveKeyPattern = regexp.MustCompile(`(?i)(authorization|cookie|token|secret|password|passwd|api[-_]?key|session|credential|signature)`)
+
+// IsSensitiveKey reports whether a header/query/body key looks like it holds a secret
+// The redact list in config.toml adds names, matched the same way as the built-in ones
+func IsSensitiveKey(key string) bool {
+	if sensitiveKeyPattern.MatchString(key) {
+		return true
+	}
+	for _, name := range config.Current().Redact {
+		if strings.Contains(strings.ToLower(key), strings.ToLower(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactMap returns a copy of a string map with sensitive values replaced
+func RedactMap(values map[string]string) map[string]string {
+	redacted := make(map[string]string, len(values))
+	for key, value := range values {
+		if IsSensitiveKey(key) {
+			value = RedactedValue
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+// RedactJSON replaces sensitive fields anywhere in a JSON document
+// Non-JSON input is returned unchanged
+func RedactJSON(data string) string {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		return data
+	}
+
+	redacted, err := json.Marshal(redactValue(doc))
+	if err != nil {
+		return data
+	}
+	return string(redacted)
+}
+
+// redactValue walks decoded JSON and replaces the values of sensitive keys
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if IsSensitiveKey(key) {
+				v[key] = RedactedValue
+			} else {
+				v[key] = redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
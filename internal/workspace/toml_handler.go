@@ -5,6 +5,9 @@ package workspace
 
 import (
 	"fmt"
+	"path"
+	"sort"
+	"strings"
 
 	lib "github.com/pelletier/go-toml"
 )
@@ -167,5 +170,83 @@ func (t *TomlHandler) Keys() []string {
 	return t.tree.Keys()
 }
 
+// LeafKeys returns the dotted path of every value, including those in nested tables
+func (t *TomlHandler) LeafKeys() []string {
+	var keys []string
+	collectLeafKeys(t.tree, "", &keys)
+	sort.Strings(keys)
+	return keys
+}
+
+// collectLeafKeys walks a tree depth first, prefixing keys with their table path
+func collectLeafKeys(tree *lib.Tree, prefix string, keys *[]string) {
+	for _, key := range tree.Keys() {
+		if subtree, isTable := tree.GetPath([]string{key}).(*lib.Tree); isTable {
+			collectLeafKeys(subtree, prefix+key+".", keys)
+			continue
+		}
+		*keys = append(*keys, prefix+key)
+	}
+}
+
+// DeletePath removes a key, or every key matching a pattern with * wildcards in any
+// segment (user.*), then removes the tables that were left empty. Returns the removed keys
+func (t *TomlHandler) DeletePath(pattern string) []string {
+	segments := strings.Split(pattern, ".")
+	var matched [][]string
+	for _, keyPath := range t.allPaths(t.tree, nil) {
+		if pathMatches(segments, keyPath) {
+			matched = append(matched, keyPath)
+		}
+	}
 
+	var removed []string
+	for _, keyPath := range matched {
+		// A match inside a table that was already removed is gone with it
+		if !t.tree.HasPath(keyPath) {
+			continue
+		}
+		t.tree.DeletePath(keyPath)
+		removed = append(removed, strings.Join(keyPath, "."))
+		t.pruneEmptyTables(keyPath[:len(keyPath)-1])
+	}
+	return removed
+}
 
+// allPaths lists the path of every table and value in the tree, parents first
+func (t *TomlHandler) allPaths(tree *lib.Tree, prefix []string) [][]string {
+	var paths [][]string
+	for _, key := range tree.Keys() {
+		keyPath := append(append([]string{}, prefix...), key)
+		paths = append(paths, keyPath)
+		if subtree, isTable := tree.GetPath([]string{key}).(*lib.Tree); isTable {
+			paths = append(paths, t.allPaths(subtree, keyPath)...)
+		}
+	}
+	return paths
+}
+
+// pruneEmptyTables removes a table and its parents while they have nothing left in them
+func (t *TomlHandler) pruneEmptyTables(tablePath []string) {
+	for len(tablePath) > 0 {
+		table, isTable := t.tree.GetPath(tablePath).(*lib.Tree)
+		if !isTable || len(table.Keys()) > 0 {
+			return
+		}
+		t.tree.DeletePath(tablePath)
+		tablePath = tablePath[:len(tablePath)-1]
+	}
+}
+
+// pathMatches compares a key path against pattern segments, each one a glob
+func pathMatches(segments, keyPath []string) bool {
+	if len(segments) != len(keyPath) {
+		return false
+	}
+	for i, segment := range segments {
+		if matched, err := path.Match(segment, keyPath[i]); err != nil || !matched {
+			return false
+		}
+	}
+	return true
+}
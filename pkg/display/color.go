@@ -0,0 +1,58 @@
+package display
+
+import (
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ANSI colors for message markers and response statuses
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorCyan   = "\033[36m"
+)
+
+// Colors stay off until SetColorMode turns them on, stdout and stderr are decided separately
+var colorStdout, colorStderr bool
+
+// SetColorMode takes "always", "never" or "auto"
+// Auto colors terminals only, and never when NO_COLOR is set
+func SetColorMode(mode string) {
+	switch mode {
+	case "always":
+		colorStdout, colorStderr = true, true
+	case "never":
+		colorStdout, colorStderr = false, false
+	default:
+		enabled := os.Getenv("NO_COLOR") == ""
+		colorStdout = enabled && term.IsTerminal(int(os.Stdout.Fd()))
+		colorStderr = enabled && term.IsTerminal(int(os.Stderr.Fd()))
+	}
+}
+
+// paint wraps text in a color when colors are on for its stream
+func paint(enabled bool, color, text string) string {
+	if !enabled {
+		return text
+	}
+	return color + text + colorReset
+}
+
+// statusColor picks a color by status class: 2xx green, 3xx cyan, 4xx yellow, 5xx red
+func statusColor(status string) string {
+	switch {
+	case strings.HasPrefix(status, "2"):
+		return colorGreen
+	case strings.HasPrefix(status, "3"):
+		return colorCyan
+	case strings.HasPrefix(status, "4"):
+		return colorYellow
+	case strings.HasPrefix(status, "5"):
+		return colorRed
+	}
+	return ""
+}
@@ -73,6 +73,9 @@ func FormatSection(title, content, metadata string) string {
 // FormatResponse creates formatted response display with status metadata
 func FormatResponse(status, contentType, timing, size string, content string) string {
 	// Build clean metadata string
+	if color := statusColor(status); color != "" {
+		status = paint(colorStdout, color, status)
+	}
 	metadata := fmt.Sprintf("%s%s%s%s%s", status, BulletSeparator, size, BulletSeparator, contentType)
 	
 	return FormatSection("Response:", content, metadata)
@@ -11,7 +11,7 @@ const (
 	ErrInvalidTimeout        = "Time(out) is money, friend! Give me actual seconds for timeout, not whatever that was supposed to be."
 	ErrPresetNotFound        = "Here's the deal, sport - preset '%s' doesn't exist in my files! Do I look like a magician to you?"
 	ErrKeyNotFound           = "Let me tell you something, gentlemen - key '%s' is nowhere in %s. Case closed!"
-	ErrInvalidTarget         = "Hold up! '%s'? That's amateur hour! Stick to the real targets: body, headers/header, query, request, variables, filters - that's how we do business!"
+	ErrInvalidTarget         = "Hold up! '%s'? That's amateur hour! Stick to the real targets: body, headers/header, query, request, variables, filters - that's how we do business!%s"
 	ErrPresetNameRequired    = "Hey hey hey! Can't work magic without knowing which preset we're talking about here!(this one is good)"
 	ErrTargetRequired        = "Trust me on this one - gonna need to specify body, headers, query, request, variables, or filters."
 	ErrKeyValueRequired      = "Bottom line, chief - I need actual key=value pairs to work with, not thin air! (this one is great)"
@@ -38,6 +38,72 @@ const (
 	ErrEmptyCurlCommand      = "Come on now, friend - you gave me an empty file! I need an actual curl command to work with!"
 	ErrCurlParseFailed       = "That curl command's not holding up under scrutiny - syntax error, plain and simple: %v"
 	ErrNoCurlURL             = "Listen pal, that curl command's missing the most important part - the URL! Can't make a case without an address!"
+	ErrCurlMultipleCommands  = "That's %d curl commands for one preset, counselor! One client per lawyer - use 'saul import curl <file>' to give each its own"
+	ErrClipboardUnavailable  = "Can't get into the clipboard - the evidence locker's sealed: %v"
+	ErrRecordUpstreamInvalid = "Upstream '%s'? I need a real http:// or https:// address to forward the case to!"
+	ErrRecordListenFailed    = "Can't set up shop on port %s - somebody else is sitting in my office: %v"
+	ErrRecordNoUpstream      = "Request for %s with no address on it - give me --upstream so I know where to forward the case!"
+	ErrImportFormatRequired  = "Import what, exactly? Tell me the format first: saul import har file.har"
+	ErrImportFormatUnknown   = "Format '%s'? Never heard of it, and I've heard of everything! Try: curl, har, postman, insomnia, bruno, openapi, http, bundle"
+	ErrImportFileRequired    = "I'm gonna need the actual file, counselor - no evidence, no case!"
+	ErrExportFormatRequired  = "Export to what? Name the format: saul [preset] export har"
+	ErrExportFormatUnknown   = "Format '%s'? Not in my filing cabinet! Try: har, http, bundle - or -o file.tar.gz to bundle presets"
+	ErrGetFormatUnknown      = "Format '%s'? I only draft in curl and http, counselor!"
+	ErrLangUnknown           = "'%s'? I don't speak that one, amigo! I'm fluent in: %s"
+	ErrLintNoSpec            = "No spec on file for '%s'! Link one first: saul %s set request openapi=spec.yaml operation=getPet"
+	ErrLintViolations        = "The spec doesn't back you up - %d violation(s) on the record"
+	ErrUnknownFlag           = "'%s'? Never heard of that flag, counselor!%s"
+	ErrUnknownCommand        = "'%s'? That's not a command in my practice!%s See 'saul help'"
+	ErrFlagNotAllowed        = "%s doesn't apply to '%s', friend - check '%s' for what does"
+	ErrFlagNeedsValue        = "%s needs a value, counselor: %s"
+	ErrFlagTakesNoValue      = "%s is a switch, not a question - it doesn't take a value"
+	ErrRequestItemInvalid    = "'%s'? Can't make heads or tails of it! Try name=value, name:=json, name==query, Header:value or @body.json"
+	ErrRequestItemJSON       = "'%s' promised JSON after := and didn't deliver: %v"
+	ErrRequestFileUpload     = "File uploads like '%s' aren't my line of work - I send JSON bodies, friend"
+	ErrRequestBodyNotJSON    = "Body file '%s' isn't JSON - I only send JSON bodies, counselor"
+	ErrRequestBodyConflict   = "Pick one, counselor - a raw @file body or fields, not both!"
+	ErrCompletionShell       = "Which shell, counselor? I do bash, zsh, fish and powershell: saul completion bash"
+	ErrRmKeyRequired         = "Remove what from %s? Name the keys: saul [preset] rm body user.email"
+	ErrRmTargetUnsupported   = "Can't take anything out of '%s' - rm works on body, headers, query and variables"
+	ErrRmPresetForm          = "'%s' is a whole preset, not a target! Deleting presets takes the long form: saul rm preset %s"
+	ErrCopyArgs              = "From where to where, counselor? saul %s <preset> <new-name>"
+	ErrPresetAlreadyExists   = "Preset '%s' already exists - I don't shred other clients' files! Pick another name or 'saul rm preset' it first"
+	ErrPresetCopyFailed      = "Couldn't get '%s' over to '%s': %v"
+	ErrPresetNameInvalid     = "'%s'? That name won't hold up - use letters and slashes like github/issues/create, no empty parts or leading dots"
+	ErrExtendsCycle          = "'%s' ends up extending itself (%s) - that's circular reasoning, and no judge buys it!"
+	ErrCompletionUnknown     = "'%s'? I don't make house calls to that shell! Try: bash, zsh, fish, powershell%s"
+	ErrConfigInvalid         = "Your settings in %s don't hold up: %v"
+	ErrConfigKeyUnknown      = "'%s'? That setting's not in my contract! I know: %s, headers.<Name>"
+	ErrConfigNotNumber       = "%s takes a number, counselor, not '%s'"
+	ErrConfigHistory         = "Keeping %d responses? Pick something between 0 and 100, friend"
+	ErrConfigChoice          = "%s can't be '%s' - your options are %s"
+	ErrConfigProxy           = "Proxy '%s'? I need a full address like http://127.0.0.1:8080"
+	ErrConfigSetFormat       = "Set it like a professional: saul config set %s=value"
+	ErrConfigAction          = "'%s'? Config does get, set and edit - that's the whole menu!%s"
+	ErrStackEmpty            = "The stack's empty, counselor - nothing pushed, nothing to pop! Try: saul pushd <preset>"
+	ErrNoPreviousPreset      = "Go back where? This terminal hasn't switched presets yet!"
+	ErrListSortField         = "Sort by '%s'? I line them up by %s - pick one!%s"
+	ErrMetaKeyUnknown        = "'%s' isn't something I keep on file - meta holds %s%s"
+	ErrSearchTerms           = "Search for what, counselor? Give me something to go on: saul search refund"
+	ErrBundleInvalid         = "'%s' isn't a saul bundle I can read: %v"
+	ErrBundleTooNew          = "That bundle was made by saul %s, and you're running %s - update first: saul update"
+	ErrBundleTerminal        = "A bundle is binary, counselor - I'm not dumping that on your screen! Use -o bundle.tar.gz"
+	ErrConflictChoice        = "--on-conflict '%s'? Your options are %s%s"
+	ErrPresetExtended        = "Hold it - %s is extended by %s, they'd be left hanging! Repoint them with 'set extends', or add -y to delete anyway"
+	ErrConfirmNeedsYes       = "No terminal to ask on, counselor - add -y if you really mean it"
+	ErrConflictNeedsChoice   = "'%s' is already taken and there's no terminal to ask on - pick one with --on-conflict %s"
+	ErrBundleEntryTooLarge   = "'%s' has %s over %d MB unpacked - that's no bundle I made, counselor"
+)
+
+const (
+	// Prompts
+	PromptDeletePresets  = "Delete %s for good? No appeals after this"
+	PromptBundleConflict = "Preset '%s' already exists - rename the new one, overwrite yours, or skip it?"
+)
+
+const (
+	// Hints appended to other messages
+	HintDidYouMean = " Did you mean '%s'?"
 )
 
 const (
@@ -47,6 +113,30 @@ const (
 	WarnResponseLarge     = "That response is huge (%d bytes), even 'loco' maybe - giving you raw JSON instead of TOML! That's just good business!"
 	WarnHistoryFailed     = "Listen, buddy - couldn't save that response to history! No biggie, but thought you should know!"
 	WarnUpdateCheckFailed = "Listen friend, couldn't check for updates right now - network's being difficult! Try again later, no big deal!"
+	WarnRecordBodySkipped = "Heads up - '%s' had a body that isn't a JSON object (arrays and plain text don't fit body.toml), recorded it without one"
+	WarnRecordTunnel      = "HTTPS to %s goes through a sealed tunnel - can't record that one. Use --upstream https://... instead"
+	WarnRecordFailed      = "Couldn't put '%s' on file, the case still went through: %v"
+	WarnSpecLoadFailed    = "Couldn't pull up the spec, skipping validation: %v"
+	WarnSpecViolations    = "Objection! %s doesn't match %s:"
+	WarnSessionNotMoved   = "Moved it, but couldn't update the open sessions: %v"
+	WarnConfigIgnored     = "%v - sticking with the defaults until it's fixed"
+	WarnExtendsOrphaned   = "Heads up - what %s extended is gone, repoint with 'saul <preset> set extends'"
+)
+
+const (
+	// Record Messages
+	InfoRecordForward = "Saul's listening on http://127.0.0.1:%s - point HTTP_PROXY at it and start talking"
+	InfoRecordReverse = "Saul's listening on http://127.0.0.1:%s - everything goes straight to %s"
+	InfoRecordSummary = "Court adjourned - recorded %d new preset(s)"
+	InfoImportSummary = "Done and dusted - imported %d preset(s)"
+)
+
+const (
+	// Store Messages, shown by saul where
+	InfoStoreSaulHome = "SAUL_HOME says the files are kept at %s"
+	InfoStoreProject  = "Project store at %s - this case stays with the repo"
+	InfoStoreXDG      = "XDG_CONFIG_HOME store at %s"
+	InfoStoreGlobal   = "Global store at %s - no .saul/ around here"
 )
 
 const (
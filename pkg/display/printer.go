@@ -10,12 +10,12 @@ import (
 
 // Error prints error messages to stderr with consistent formatting
 func Error(msg string) {
-	fmt.Fprintf(os.Stderr, "✗ %s\n", msg)
+	fmt.Fprintf(os.Stderr, "%s %s\n", paint(colorStderr, colorRed, "✗"), msg)
 }
 
 // Success prints success messages to stdout
 func Success(msg string) {
-	fmt.Printf("✓ %s\n", msg)
+	fmt.Printf("%s %s\n", paint(colorStdout, colorGreen, "✓"), msg)
 }
 
 // Warning prints warning messages to stdout
@@ -25,12 +25,12 @@ func Warning(msg string) {
 
 // Info prints informational messages to stdout
 func Info(msg string) {
-	fmt.Printf("» %s\n", msg)
+	fmt.Printf("%s %s\n", paint(colorStdout, colorCyan, "»"), msg)
 }
 
 // Tip prints helpful tips/hints to stdout
 func Tip(msg string) {
-	fmt.Printf("→ %s\n", msg)
+	fmt.Printf("%s %s\n", paint(colorStdout, colorYellow, "→"), msg)
 }
 
 // Plain prints messages without any formatting or prefixes